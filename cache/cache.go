@@ -0,0 +1,103 @@
+// Package cache provides a concurrency-safe cache of the parsed struct tags of the struct fields.
+package cache
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/fatih/structtag"
+	"github.com/ldez/structtags/variant/fatih"
+)
+
+// Cloner is implemented by the types that can be copied.
+// All the variants implement it (except fatih, see [fatih.Clone]).
+type Cloner[T any] interface {
+	// Clone returns a copy.
+	Clone() T
+}
+
+// Cache caches the parsed struct tags, by struct type and field index.
+// It is safe for concurrent use.
+//
+// When the parsed type implements [Cloner] or is a [*structtag.Tags], a copy is returned,
+// otherwise the returned data is shared and must not be modified.
+type Cache[T any] struct {
+	parse func(tag string) (T, error)
+
+	// entries is a map of fieldKey to *entry[T].
+	entries sync.Map
+}
+
+// fieldKey identifies a struct field.
+type fieldKey struct {
+	typ   reflect.Type
+	index int
+}
+
+// entry is the result of the parsing of a struct tag.
+type entry[T any] struct {
+	data T
+	err  error
+}
+
+// New creates a [Cache] using a parse function.
+func New[T any](parse func(tag string) (T, error)) *Cache[T] {
+	return &Cache[T]{parse: parse}
+}
+
+// NewVariant creates a [Cache] using the Parse function of a variant and its options.
+//
+//	c := cache.NewVariant(structured.Parse, structured.WithEscapeComma())
+func NewVariant[T, O any](parse func(tag string, options ...O) (T, error), options ...O) *Cache[T] {
+	return New(func(tag string) (T, error) {
+		return parse(tag, options...)
+	})
+}
+
+// Field returns the parsed struct tag of the field i of a struct type.
+// It panics if the type is not a struct type, or if i is not in the range [0, NumField()).
+func (c *Cache[T]) Field(typ reflect.Type, i int) (T, error) {
+	key := fieldKey{typ: typ, index: i}
+
+	if e, ok := c.entries.Load(key); ok {
+		return c.result(e.(*entry[T]))
+	}
+
+	data, err := c.parse(string(typ.Field(i).Tag))
+
+	e, _ := c.entries.LoadOrStore(key, &entry[T]{data: data, err: err})
+
+	return c.result(e.(*entry[T]))
+}
+
+// Fields returns the parsed struct tags of all the fields of a struct type.
+// The index of a struct tag is the index of the field.
+// It panics if the type is not a struct type.
+func (c *Cache[T]) Fields(typ reflect.Type) ([]T, error) {
+	fields := make([]T, typ.NumField())
+
+	for i := range fields {
+		data, err := c.Field(typ, i)
+		if err != nil {
+			return nil, err
+		}
+
+		fields[i] = data
+	}
+
+	return fields, nil
+}
+
+func (c *Cache[T]) result(e *entry[T]) (T, error) {
+	switch data := any(e.data).(type) {
+	case Cloner[T]:
+		return data.Clone(), e.err
+
+	case *structtag.Tags:
+		if c, ok := any(fatih.Clone(data)).(T); ok {
+			return c, e.err
+		}
+	}
+
+	return e.data, e.err
+}
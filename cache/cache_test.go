@@ -0,0 +1,176 @@
+package cache
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/fatih/structtag"
+	"github.com/ldez/structtags/parser"
+	"github.com/ldez/structtags/variant/fatih"
+	mapsraw "github.com/ldez/structtags/variant/maps/raw"
+	"github.com/ldez/structtags/variant/structured"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sample struct {
+	Name    string `json:"name,omitempty" yaml:"name"`
+	Age     int    `json:"age"`
+	Ignored bool
+	Invalid string `json:"invalid" json:"duplicate"`
+}
+
+func TestCache_Field(t *testing.T) {
+	var calls int
+
+	c := New(func(tag string) (mapsraw.Tag, error) {
+		calls++
+
+		return mapsraw.Parse(tag)
+	})
+
+	typ := reflect.TypeFor[sample]()
+
+	for range 3 {
+		tags, err := c.Field(typ, 0)
+		require.NoError(t, err)
+
+		assert.Equal(t, mapsraw.Tag{"json": "name,omitempty", "yaml": "name"}, tags)
+	}
+
+	assert.Equal(t, 1, calls)
+
+	tags, err := c.Field(typ, 2)
+	require.NoError(t, err)
+
+	assert.Empty(t, tags)
+	assert.Equal(t, 2, calls)
+}
+
+func TestCache_Field_error(t *testing.T) {
+	c := NewVariant(mapsraw.Parse, mapsraw.WithDuplicateKeysMode(mapsraw.DuplicateKeysDeny))
+
+	typ := reflect.TypeFor[sample]()
+
+	for range 2 {
+		_, err := c.Field(typ, 3)
+		require.ErrorIs(t, err, parser.ErrDuplicateKey)
+	}
+}
+
+func TestCache_Field_clone(t *testing.T) {
+	c := NewVariant(structured.Parse)
+
+	typ := reflect.TypeFor[sample]()
+
+	tags, err := c.Field(typ, 0)
+	require.NoError(t, err)
+
+	tags.Get("json").RawValue = "modified"
+	tags.Delete("yaml")
+
+	tags, err = c.Field(typ, 0)
+	require.NoError(t, err)
+
+	assert.Equal(t, `json:"name,omitempty" yaml:"name"`, tags.Render())
+}
+
+func TestCache_Field_fatih(t *testing.T) {
+	c := New(func(tag string) (*structtag.Tags, error) {
+		return fatih.Parse(tag, false)
+	})
+
+	typ := reflect.TypeFor[sample]()
+
+	tags, err := c.Field(typ, 0)
+	require.NoError(t, err)
+
+	tag, err := tags.Get("json")
+	require.NoError(t, err)
+
+	tag.Name = "modified"
+	tag.Options[0] = "modified"
+	tags.Delete("yaml")
+
+	tags, err = c.Field(typ, 0)
+	require.NoError(t, err)
+
+	assert.Equal(t, `json:"name,omitempty" yaml:"name"`, tags.String())
+}
+
+func TestCache_Field_shared(t *testing.T) {
+	c := New(func(tag string) (*string, error) {
+		return &tag, nil
+	})
+
+	typ := reflect.TypeFor[sample]()
+
+	a, err := c.Field(typ, 1)
+	require.NoError(t, err)
+
+	b, err := c.Field(typ, 1)
+	require.NoError(t, err)
+
+	// Without Clone method, the data is shared.
+	assert.Same(t, a, b)
+}
+
+func TestCache_Fields(t *testing.T) {
+	c := NewVariant(mapsraw.Parse)
+
+	tags, err := c.Fields(reflect.TypeFor[sample]())
+	require.NoError(t, err)
+
+	expected := []mapsraw.Tag{
+		{"json": "name,omitempty", "yaml": "name"},
+		{"json": "age"},
+		nil,
+		{"json": "invalid"},
+	}
+
+	assert.Equal(t, expected, tags)
+}
+
+func TestCache_Fields_error(t *testing.T) {
+	c := NewVariant(mapsraw.Parse, mapsraw.WithDuplicateKeysMode(mapsraw.DuplicateKeysDeny))
+
+	tags, err := c.Fields(reflect.TypeFor[sample]())
+	require.ErrorIs(t, err, parser.ErrDuplicateKey)
+
+	assert.Nil(t, tags)
+}
+
+func TestCache_concurrency(t *testing.T) {
+	c := NewVariant(structured.Parse)
+
+	typ := reflect.TypeFor[sample]()
+
+	var wg sync.WaitGroup
+
+	for range 20 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range typ.NumField() {
+				tags, err := c.Field(typ, i)
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				// The modifications must not be visible by the other goroutines.
+				_ = tags.Add(&structured.Entry{Key: "other", RawValue: "value"})
+				tags.Delete("json")
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	tags, err := c.Field(typ, 0)
+	require.NoError(t, err)
+
+	assert.Equal(t, `json:"name,omitempty" yaml:"name"`, tags.Render())
+}
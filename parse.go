@@ -0,0 +1,226 @@
+package structtags
+
+import (
+	"github.com/fatih/structtag"
+	"github.com/ldez/structtags/parser"
+	"github.com/ldez/structtags/variant/fatih"
+	mapsmultikeys "github.com/ldez/structtags/variant/maps/multikeys"
+	mapsraw "github.com/ldez/structtags/variant/maps/raw"
+	mapsvalues "github.com/ldez/structtags/variant/maps/values"
+	sliceraw "github.com/ldez/structtags/variant/slices/raw"
+	slicevalues "github.com/ldez/structtags/variant/slices/values"
+	"github.com/ldez/structtags/variant/structured"
+)
+
+// Config is the configuration shared by all the targets of [Parse].
+// The options not supported by a target are ignored.
+type Config struct {
+	// EscapeComma is used to escape the comma character within the value.
+	EscapeComma bool
+
+	// DuplicateKeys defines how the duplicate keys are handled.
+	// If nil, the default mode of the target is used (e.g., DuplicateKeysAllow for [AsMapMultikeys]).
+	DuplicateKeys parser.DuplicateKeysPolicy
+
+	// TagOptions are the options of the struct tag parser.
+	TagOptions []parser.TagOption
+
+	// ValueOptions are the options of the value parser.
+	ValueOptions []parser.ValueOption
+
+	// CST keeps the original text of the struct tag (structured target only).
+	CST bool
+}
+
+// Option configures [Parse].
+type Option func(*Config)
+
+// WithEscapeComma escapes the comma character within the value with a backslash.
+func WithEscapeComma() Option {
+	return func(cfg *Config) {
+		cfg.EscapeComma = true
+	}
+}
+
+// WithEscapeCommaKeys escapes the comma only in the values of the given keys (see [parser.ForKeys]).
+func WithEscapeCommaKeys(keys ...string) Option {
+	return func(cfg *Config) {
+		cfg.ValueOptions = append(cfg.ValueOptions, parser.ForKeys(keys, parser.WithEscapeComma()))
+	}
+}
+
+// WithDuplicateKeysMode sets how the duplicate keys are handled.
+func WithDuplicateKeysMode(mode parser.DuplicateKeysMode) Option {
+	return func(cfg *Config) {
+		cfg.DuplicateKeys = mode
+	}
+}
+
+// WithDuplicateKeysPolicy sets how the duplicate keys are handled, key by key (see [parser.DuplicateKeysPerKey]).
+func WithDuplicateKeysPolicy(policy parser.DuplicateKeysPolicy) Option {
+	return func(cfg *Config) {
+		cfg.DuplicateKeys = policy
+	}
+}
+
+// WithTagOptions sets the options of the struct tag parser (see [parser.Tag]).
+func WithTagOptions(options ...parser.TagOption) Option {
+	return func(cfg *Config) {
+		cfg.TagOptions = append(cfg.TagOptions, options...)
+	}
+}
+
+// WithKeys only sends the pairs with the given keys to the target, the other pairs are only syntax-checked (see [parser.WithKeys]).
+func WithKeys(keys ...string) Option {
+	return func(cfg *Config) {
+		cfg.TagOptions = append(cfg.TagOptions, parser.WithKeys(keys...))
+	}
+}
+
+// WithoutKeys doesn't send the pairs with the given keys to the target, these pairs are only syntax-checked (see [parser.WithoutKeys]).
+func WithoutKeys(keys ...string) Option {
+	return func(cfg *Config) {
+		cfg.TagOptions = append(cfg.TagOptions, parser.WithoutKeys(keys...))
+	}
+}
+
+// WithValueOptions sets the options of the value parser (see [parser.Value]).
+func WithValueOptions(options ...parser.ValueOption) Option {
+	return func(cfg *Config) {
+		cfg.ValueOptions = append(cfg.ValueOptions, options...)
+	}
+}
+
+// WithCST keeps the original text of the struct tag (see [structured.WithCST]).
+func WithCST() Option {
+	return func(cfg *Config) {
+		cfg.CST = true
+	}
+}
+
+// Target parses a struct tag to a [T] (e.g., [AsMap], [AsStructured]).
+type Target[T any] func(tag string, cfg Config) (T, error)
+
+// Parse parses a struct tag with a target.
+//
+//	data, err := structtags.Parse(tag, structtags.AsMapValues, structtags.WithEscapeComma())
+func Parse[T any](tag string, target Target[T], options ...Option) (T, error) {
+	var cfg Config
+
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return target(tag, cfg)
+}
+
+// AsMap is the [Target] of [ParseToMap].
+func AsMap(tag string, cfg Config) (mapsraw.Tag, error) {
+	options := []mapsraw.Option{
+		mapsraw.WithTagOptions(cfg.TagOptions...),
+	}
+
+	if cfg.DuplicateKeys != nil {
+		options = append(options, mapsraw.WithDuplicateKeysPolicy(cfg.DuplicateKeys))
+	}
+
+	return mapsraw.Parse(tag, options...)
+}
+
+// AsMapMultikeys is the [Target] of [ParseToMapMultikeys].
+func AsMapMultikeys(tag string, cfg Config) (mapsmultikeys.Tag, error) {
+	options := []mapsmultikeys.Option{
+		mapsmultikeys.WithTagOptions(cfg.TagOptions...),
+	}
+
+	if cfg.DuplicateKeys != nil {
+		options = append(options, mapsmultikeys.WithDuplicateKeysPolicy(cfg.DuplicateKeys))
+	}
+
+	return mapsmultikeys.Parse(tag, options...)
+}
+
+// AsMapValues is the [Target] of [ParseToMapValues].
+func AsMapValues(tag string, cfg Config) (mapsvalues.Tag, error) {
+	options := []mapsvalues.Option{
+		mapsvalues.WithTagOptions(cfg.TagOptions...),
+		mapsvalues.WithValueOptions(cfg.ValueOptions...),
+	}
+
+	if cfg.EscapeComma {
+		options = append(options, mapsvalues.WithEscapeComma())
+	}
+
+	if cfg.DuplicateKeys != nil {
+		options = append(options, mapsvalues.WithDuplicateKeysPolicy(cfg.DuplicateKeys))
+	}
+
+	return mapsvalues.Parse(tag, options...)
+}
+
+// AsSlice is the [Target] of [ParseToSlice].
+func AsSlice(tag string, cfg Config) (sliceraw.Tags, error) {
+	options := []sliceraw.Option{
+		sliceraw.WithTagOptions(cfg.TagOptions...),
+	}
+
+	if cfg.DuplicateKeys != nil {
+		options = append(options, sliceraw.WithDuplicateKeysPolicy(cfg.DuplicateKeys))
+	}
+
+	return sliceraw.Parse(tag, options...)
+}
+
+// AsSliceValues is the [Target] of [ParseToSliceValues].
+func AsSliceValues(tag string, cfg Config) (slicevalues.Tags, error) {
+	options := []slicevalues.Option{
+		slicevalues.WithTagOptions(cfg.TagOptions...),
+		slicevalues.WithValueOptions(cfg.ValueOptions...),
+	}
+
+	if cfg.EscapeComma {
+		options = append(options, slicevalues.WithEscapeComma())
+	}
+
+	if cfg.DuplicateKeys != nil {
+		options = append(options, slicevalues.WithDuplicateKeysPolicy(cfg.DuplicateKeys))
+	}
+
+	return slicevalues.Parse(tag, options...)
+}
+
+// AsStructured is the [Target] of [ParseToStructured].
+func AsStructured(tag string, cfg Config) (*structured.Tag, error) {
+	options := []structured.Option{
+		structured.WithTagOptions(cfg.TagOptions...),
+		structured.WithValueOptions(cfg.ValueOptions...),
+	}
+
+	if cfg.EscapeComma {
+		options = append(options, structured.WithEscapeComma())
+	}
+
+	if cfg.DuplicateKeys != nil {
+		options = append(options, structured.WithDuplicateKeysPolicy(cfg.DuplicateKeys))
+	}
+
+	if cfg.CST {
+		options = append(options, structured.WithCST())
+	}
+
+	return structured.Parse(tag, options...)
+}
+
+// AsFatih is the [Target] of [ParseToFatih].
+func AsFatih(tag string, cfg Config) (*structtag.Tags, error) {
+	options := []fatih.Option{
+		fatih.WithTagOptions(cfg.TagOptions...),
+		fatih.WithValueOptions(cfg.ValueOptions...),
+	}
+
+	if cfg.DuplicateKeys != nil {
+		options = append(options, fatih.WithDuplicateKeysPolicy(cfg.DuplicateKeys))
+	}
+
+	return fatih.Parse(tag, cfg.EscapeComma, options...)
+}
@@ -0,0 +1,188 @@
+package structtags
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/fatih/structtag"
+	"github.com/ldez/structtags/parser"
+	mapsmultikeys "github.com/ldez/structtags/variant/maps/multikeys"
+	mapsraw "github.com/ldez/structtags/variant/maps/raw"
+	mapsvalues "github.com/ldez/structtags/variant/maps/values"
+	sliceraw "github.com/ldez/structtags/variant/slices/raw"
+	slicevalues "github.com/ldez/structtags/variant/slices/values"
+	"github.com/ldez/structtags/variant/structured"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTag = `a:"1\\,2,3" b:"4" a:"5"`
+
+func TestParse_AsMap(t *testing.T) {
+	data, err := Parse(testTag, AsMap)
+	require.NoError(t, err)
+
+	assert.Equal(t, mapsraw.Tag{"a": `1\,2,3`, "b": "4"}, data)
+
+	_, err = Parse(testTag, AsMap, WithDuplicateKeysMode(parser.DuplicateKeysDeny))
+	require.ErrorIs(t, err, parser.ErrDuplicateKey)
+}
+
+func TestParse_AsMapMultikeys(t *testing.T) {
+	data, err := Parse(testTag, AsMapMultikeys)
+	require.NoError(t, err)
+
+	assert.Equal(t, mapsmultikeys.Tag{"a": {`1\,2,3`, "5"}, "b": {"4"}}, data)
+}
+
+func TestParse_AsMapValues(t *testing.T) {
+	data, err := Parse(testTag, AsMapValues, WithEscapeComma(), WithDuplicateKeysMode(parser.DuplicateKeysAllow))
+	require.NoError(t, err)
+
+	assert.Equal(t, mapsvalues.Tag{"a": {`1\,2`, "3", "5"}, "b": {"4"}}, data)
+}
+
+func TestParse_AsSlice(t *testing.T) {
+	data, err := Parse(testTag, AsSlice, WithDuplicateKeysMode(parser.DuplicateKeysAllow))
+	require.NoError(t, err)
+
+	expected := []string{"a", "b", "a"}
+
+	keys := make([]string, 0, len(data))
+	for _, tag := range data {
+		keys = append(keys, tag.Key)
+	}
+
+	assert.Equal(t, expected, keys)
+	assert.IsType(t, sliceraw.Tags{}, data)
+}
+
+func TestParse_AsSliceValues(t *testing.T) {
+	data, err := Parse(testTag, AsSliceValues, WithEscapeComma(), WithValueOptions(parser.WithUnescape()))
+	require.NoError(t, err)
+
+	require.Len(t, data, 2)
+
+	assert.IsType(t, slicevalues.Tags{}, data)
+	assert.Equal(t, []string{"1,2", "3"}, data[0].Values)
+}
+
+func TestParse_AsStructured(t *testing.T) {
+	data, err := Parse(`a:"1"  b:"2"`, AsStructured, WithCST())
+	require.NoError(t, err)
+
+	data.Get("b").RawValue = "3"
+
+	assert.Equal(t, `a:"1"  b:"3"`, data.Render())
+
+	entries := slices.Collect(data.Seq())
+	require.Len(t, entries, 2)
+	assert.IsType(t, &structured.Entry{}, entries[0])
+}
+
+func TestParse_AsFatih(t *testing.T) {
+	data, err := Parse(`json:"a,omitempty"`, AsFatih)
+	require.NoError(t, err)
+
+	tag, err := data.Get("json")
+	require.NoError(t, err)
+
+	assert.Equal(t, &structtag.Tag{Key: "json", Name: "a", Options: []string{"omitempty"}}, tag)
+}
+
+func TestParse_tagOptions(t *testing.T) {
+	_, err := Parse(`a:"1"  b:"2"`, AsMap, WithTagOptions(parser.WithStrict()))
+	require.ErrorIs(t, err, parser.ErrMultipleSpaces)
+}
+
+// Library code can take the output shape as a type parameter.
+func parseAll[T any](tags []string, target Target[T]) ([]T, error) {
+	var result []T
+
+	for _, tag := range tags {
+		data, err := Parse(tag, target)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, data)
+	}
+
+	return result, nil
+}
+
+func TestParse_generic(t *testing.T) {
+	data, err := parseAll([]string{`a:"1"`, `b:"2"`}, AsMap)
+	require.NoError(t, err)
+
+	assert.Equal(t, []mapsraw.Tag{{"a": "1"}, {"b": "2"}}, data)
+}
+
+func TestParse_duplicateKeys(t *testing.T) {
+	policy := WithDuplicateKeysPolicy(parser.DuplicateKeysPerKey{
+		Default: parser.DuplicateKeysLastWins,
+		Keys:    map[string]parser.DuplicateKeysMode{"b": parser.DuplicateKeysMerge},
+	})
+
+	tag := `a:"1" b:"2" a:"3" b:"4"`
+
+	m, err := Parse(tag, AsMap, policy)
+	require.NoError(t, err)
+
+	assert.Equal(t, mapsraw.Tag{"a": "3", "b": "2,4"}, m)
+
+	mk, err := Parse(tag, AsMapMultikeys, policy)
+	require.NoError(t, err)
+
+	assert.Equal(t, mapsmultikeys.Tag{"a": {"3"}, "b": {"2,4"}}, mk)
+
+	mv, err := Parse(tag, AsMapValues, policy)
+	require.NoError(t, err)
+
+	assert.Equal(t, mapsvalues.Tag{"a": {"3"}, "b": {"2", "4"}}, mv)
+
+	s, err := Parse(tag, AsSlice, policy)
+	require.NoError(t, err)
+
+	assert.Equal(t, `a:"3" b:"2,4"`, s.String())
+
+	sv, err := Parse(tag, AsSliceValues, policy)
+	require.NoError(t, err)
+
+	assert.Equal(t, `a:"3" b:"2,4"`, sv.String())
+
+	st, err := Parse(tag, AsStructured, policy)
+	require.NoError(t, err)
+
+	assert.Equal(t, `a:"3" b:"2,4"`, st.Render())
+
+	f, err := Parse(tag, AsFatih, policy)
+	require.NoError(t, err)
+
+	assert.Equal(t, `a:"3" b:"2,4"`, f.String())
+}
+
+func TestParse_escapeCommaKeys(t *testing.T) {
+	tag := `default:"a\\,b" json:"name,omitempty"`
+
+	data, err := Parse(tag, AsMapValues, WithEscapeCommaKeys("default", "env-default"))
+	require.NoError(t, err)
+
+	assert.Equal(t, mapsvalues.Tag{"default": {`a\,b`}, "json": {"name", "omitempty"}}, data)
+
+	f, err := Parse(tag, AsFatih, WithEscapeCommaKeys("default"))
+	require.NoError(t, err)
+
+	tagDefault, err := f.Get("default")
+	require.NoError(t, err)
+
+	assert.Equal(t, &structtag.Tag{Key: "default", Name: `a\,b`}, tagDefault)
+}
+
+func TestParse_withKeys(t *testing.T) {
+	// The options are forwarded to the variant.
+	data, err := Parse(`json:"a,b" yaml:"c" xml:"d"`, AsSliceValues, WithKeys("json", "xml"), WithoutKeys("xml"))
+	require.NoError(t, err)
+
+	assert.Equal(t, `json:"a,b"`, data.String())
+}
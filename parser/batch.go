@@ -0,0 +1,89 @@
+package parser
+
+import (
+	"iter"
+	"runtime"
+	"sync"
+)
+
+// Result is the result of the parsing of a struct tag by [Batch].
+type Result[T any] struct {
+	// Tag is the parsed struct tag.
+	Tag string
+
+	// Data is the data returned by the [Filler].
+	Data T
+
+	// Err is the parsing error (see [Tag]).
+	Err error
+}
+
+// Batch parses struct tags concurrently, with a bounded number of workers.
+// Each struct tag is parsed by [Tag] with a new [Filler] created by newFiller.
+//
+// The results are in the same order as the struct tags,
+// and an error only affects the result of the related struct tag.
+// If workers is less than 1, the number of workers is [runtime.GOMAXPROCS].
+func Batch[T any](tags iter.Seq[string], newFiller func() Filler[T], workers int, options ...TagOption) []Result[T] {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	type job struct {
+		index int
+		tag   string
+	}
+
+	type indexedResult struct {
+		index  int
+		result Result[T]
+	}
+
+	jobs := make(chan job, workers)
+	out := make(chan indexedResult, workers)
+
+	go func() {
+		defer close(jobs)
+
+		index := 0
+
+		for tag := range tags {
+			jobs <- job{index: index, tag: tag}
+
+			index++
+		}
+	}()
+
+	var wg sync.WaitGroup
+
+	for range workers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := range jobs {
+				data, err := Tag(j.tag, newFiller(), options...)
+
+				out <- indexedResult{index: j.index, result: Result[T]{Tag: j.tag, Data: data, Err: err}}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	var results []Result[T]
+
+	for r := range out {
+		if r.index >= len(results) {
+			results = append(results, make([]Result[T], r.index+1-len(results))...)
+		}
+
+		results[r.index] = r.result
+	}
+
+	return results
+}
@@ -0,0 +1,72 @@
+package parser
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestFiller() Filler[[]TestTag] {
+	return &TestFiller{}
+}
+
+func TestBatch(t *testing.T) {
+	tags := []string{`a:"1"`, `b:2`, ``, `c:"3" d:"4"`}
+
+	results := Batch(slices.Values(tags), newTestFiller, 2)
+
+	require.Len(t, results, 4)
+
+	assert.Equal(t, Result[[]TestTag]{Tag: `a:"1"`, Data: []TestTag{{Key: "a", Value: "1"}}}, results[0])
+
+	assert.Equal(t, `b:2`, results[1].Tag)
+	assert.Nil(t, results[1].Data)
+	require.ErrorIs(t, results[1].Err, ErrMissingOpeningQuote)
+
+	assert.Equal(t, Result[[]TestTag]{}, results[2])
+
+	assert.Equal(t, Result[[]TestTag]{
+		Tag:  `c:"3" d:"4"`,
+		Data: []TestTag{{Key: "c", Value: "3"}, {Key: "d", Value: "4"}},
+	}, results[3])
+}
+
+func TestBatch_order(t *testing.T) {
+	tags := make([]string, 1000)
+	for i := range tags {
+		tags[i] = fmt.Sprintf(`n:"%d"`, i)
+	}
+
+	for _, workers := range []int{0, 1, 8} {
+		t.Run(fmt.Sprintf("%d workers", workers), func(t *testing.T) {
+			t.Parallel()
+
+			results := Batch(slices.Values(tags), newTestFiller, workers)
+
+			require.Len(t, results, len(tags))
+
+			for i, result := range results {
+				require.NoError(t, result.Err)
+
+				assert.Equal(t, tags[i], result.Tag)
+				assert.Equal(t, []TestTag{{Key: "n", Value: fmt.Sprint(i)}}, result.Data)
+			}
+		})
+	}
+}
+
+func TestBatch_options(t *testing.T) {
+	results := Batch(slices.Values([]string{`a:"1"  b:"2"`}), newTestFiller, 1, WithStrict())
+
+	require.Len(t, results, 1)
+	require.ErrorIs(t, results[0].Err, ErrMultipleSpaces)
+}
+
+func TestBatch_empty(t *testing.T) {
+	results := Batch(slices.Values([]string(nil)), newTestFiller, 4)
+
+	assert.Empty(t, results)
+}
@@ -0,0 +1,130 @@
+package parser
+
+// Sink is implemented by the types that receive the key/value pairs of a struct tag (e.g., a [Filler]).
+type Sink interface {
+	Fill(key, value string) error
+}
+
+// fill forwards a pair to a sink, with the location if the sink implements [SpanFiller].
+func fill(sink Sink, key, value string, spans Spans) error {
+	if spanFiller, ok := sink.(SpanFiller); ok {
+		return spanFiller.FillSpans(key, value, spans)
+	}
+
+	return sink.Fill(key, value)
+}
+
+// Tee creates a [Filler] forwarding each pair to the filler and to the other sinks.
+// A single parsing can fill several data (e.g., a map and a structured tag).
+// The data is the data of the filler, and the forwarding stops at the first error.
+func Tee[T any](filler Filler[T], others ...Sink) Filler[T] {
+	return &teeFiller[T]{filler: filler, others: others}
+}
+
+type teeFiller[T any] struct {
+	filler Filler[T]
+	others []Sink
+}
+
+func (f *teeFiller[T]) Data() T {
+	return f.filler.Data()
+}
+
+func (f *teeFiller[T]) Fill(key, value string) error {
+	return f.FillSpans(key, value, Spans{})
+}
+
+func (f *teeFiller[T]) FillSpans(key, value string, spans Spans) error {
+	if err := fill(f.filler, key, value, spans); err != nil {
+		return err
+	}
+
+	for _, other := range f.others {
+		if err := fill(other, key, value, spans); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FilterKeys creates a [Filler] forwarding only the pairs with a key accepted by keep.
+func FilterKeys[T any](filler Filler[T], keep func(key string) bool) Filler[T] {
+	return &filterFiller[T]{filler: filler, keep: keep}
+}
+
+type filterFiller[T any] struct {
+	filler Filler[T]
+	keep   func(key string) bool
+}
+
+func (f *filterFiller[T]) Data() T {
+	return f.filler.Data()
+}
+
+func (f *filterFiller[T]) Fill(key, value string) error {
+	return f.FillSpans(key, value, Spans{})
+}
+
+func (f *filterFiller[T]) FillSpans(key, value string, spans Spans) error {
+	if !f.keep(key) {
+		return nil
+	}
+
+	return fill(f.filler, key, value, spans)
+}
+
+// Transform creates a [Filler] rewriting the keys and the values before forwarding them.
+// The spans are the location of the original key and value.
+func Transform[T any](filler Filler[T], transform func(key, value string) (string, string, error)) Filler[T] {
+	return &transformFiller[T]{filler: filler, transform: transform}
+}
+
+type transformFiller[T any] struct {
+	filler    Filler[T]
+	transform func(key, value string) (string, string, error)
+}
+
+func (f *transformFiller[T]) Data() T {
+	return f.filler.Data()
+}
+
+func (f *transformFiller[T]) Fill(key, value string) error {
+	return f.FillSpans(key, value, Spans{})
+}
+
+func (f *transformFiller[T]) FillSpans(key, value string, spans Spans) error {
+	key, value, err := f.transform(key, value)
+	if err != nil {
+		return err
+	}
+
+	return fill(f.filler, key, value, spans)
+}
+
+// Validate creates a [Filler] validating each pair before forwarding it.
+// The validation errors are returned as [*SyntaxError] ([KindInvalidValue]) wrapping the original error.
+func Validate[T any](filler Filler[T], validate func(key, value string) error) Filler[T] {
+	return &validateFiller[T]{filler: filler, validate: validate}
+}
+
+type validateFiller[T any] struct {
+	filler   Filler[T]
+	validate func(key, value string) error
+}
+
+func (f *validateFiller[T]) Data() T {
+	return f.filler.Data()
+}
+
+func (f *validateFiller[T]) Fill(key, value string) error {
+	return f.FillSpans(key, value, Spans{})
+}
+
+func (f *validateFiller[T]) FillSpans(key, value string, spans Spans) error {
+	if err := f.validate(key, value); err != nil {
+		return &SyntaxError{Kind: KindInvalidValue, Key: key, Err: err}
+	}
+
+	return fill(f.filler, key, value, spans)
+}
@@ -0,0 +1,141 @@
+package parser
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTee(t *testing.T) {
+	other := &TestFiller{}
+	spanFiller := &TestSpanFiller{}
+
+	data, err := Tag(`a:"1" b:"2"`, Tee(&TestFiller{}, other, spanFiller))
+	require.NoError(t, err)
+
+	expected := []TestTag{{Key: "a", Value: "1"}, {Key: "b", Value: "2"}}
+
+	assert.Equal(t, expected, data)
+	assert.Equal(t, expected, other.Data())
+
+	expectedSpans := []TestSpanTag{
+		{Key: "a", Value: "1", Spans: Spans{Key: Span{Start: 0, End: 1}, QuotedValue: Span{Start: 2, End: 5}, Value: Span{Start: 3, End: 4}}},
+		{Key: "b", Value: "2", Spans: Spans{Key: Span{Start: 6, End: 7}, QuotedValue: Span{Start: 8, End: 11}, Value: Span{Start: 9, End: 10}}},
+	}
+
+	assert.Equal(t, expectedSpans, spanFiller.Data())
+}
+
+func TestTee_error(t *testing.T) {
+	other := &TestFiller{}
+
+	_, err := Tag(`a:"1" oops:"2" b:"3"`, Tee(&TestFiller{}, other))
+	require.EqualError(t, err, "oops")
+
+	assert.Equal(t, []TestTag{{Key: "a", Value: "1"}}, other.Data())
+}
+
+func TestFilterKeys(t *testing.T) {
+	keep := func(key string) bool { return key != "b" }
+
+	data, err := Tag(`a:"1" b:"2" c:"3"`, FilterKeys(&TestFiller{}, keep))
+	require.NoError(t, err)
+
+	assert.Equal(t, []TestTag{{Key: "a", Value: "1"}, {Key: "c", Value: "3"}}, data)
+
+	spanFiller := &TestSpanFiller{}
+
+	_, err = Tag(`a:"1" b:"2"`, FilterKeys[[]TestSpanTag](spanFiller, keep))
+	require.NoError(t, err)
+
+	expectedSpans := []TestSpanTag{
+		{Key: "a", Value: "1", Spans: Spans{Key: Span{Start: 0, End: 1}, QuotedValue: Span{Start: 2, End: 5}, Value: Span{Start: 3, End: 4}}},
+	}
+
+	assert.Equal(t, expectedSpans, spanFiller.Data())
+}
+
+func TestTransform(t *testing.T) {
+	transform := func(key, value string) (string, string, error) {
+		if key == "bad" {
+			return "", "", errors.New("bad key")
+		}
+
+		return strings.ToLower(key), strings.TrimSpace(value), nil
+	}
+
+	data, err := Tag(`A:" 1 " b:"2"`, Transform(&TestFiller{}, transform))
+	require.NoError(t, err)
+
+	assert.Equal(t, []TestTag{{Key: "a", Value: "1"}, {Key: "b", Value: "2"}}, data)
+
+	_, err = Tag(`a:"1" bad:"2"`, Transform(&TestFiller{}, transform))
+	require.EqualError(t, err, "bad key")
+}
+
+func TestValidate(t *testing.T) {
+	errEmpty := errors.New("empty value")
+
+	validate := func(_, value string) error {
+		if value == "" {
+			return errEmpty
+		}
+
+		return nil
+	}
+
+	data, err := Tag(`a:"1" b:"2"`, Validate(&TestFiller{}, validate))
+	require.NoError(t, err)
+
+	assert.Equal(t, []TestTag{{Key: "a", Value: "1"}, {Key: "b", Value: "2"}}, data)
+
+	_, err = Tag(`a:"1" b:""`, Validate(&TestFiller{}, validate))
+	require.EqualError(t, err, "invalid struct tag value `a:\"1\" b:\"\"`: empty value")
+	require.ErrorIs(t, err, ErrInvalidValue)
+	require.ErrorIs(t, err, errEmpty)
+
+	var syntaxErr *SyntaxError
+	require.ErrorAs(t, err, &syntaxErr)
+
+	assert.Equal(t, KindInvalidValue, syntaxErr.Kind)
+	assert.Equal(t, 6, syntaxErr.Offset)
+	assert.Equal(t, "b", syntaxErr.Key)
+}
+
+func TestValidate_recovery(t *testing.T) {
+	validate := func(key, _ string) error {
+		if key == "b" {
+			return errors.New("invalid")
+		}
+
+		return nil
+	}
+
+	data, err := Tag(`a:"1" b:"2" c:"3"`, Validate(&TestFiller{}, validate), WithRecovery())
+	require.ErrorIs(t, err, ErrInvalidValue)
+
+	assert.Equal(t, []TestTag{{Key: "a", Value: "1"}, {Key: "c", Value: "3"}}, data)
+}
+
+func TestCombinators(t *testing.T) {
+	other := &TestFiller{}
+
+	filler := Tee(
+		FilterKeys(
+			Transform(&TestFiller{}, func(key, value string) (string, string, error) {
+				return key, strings.ToUpper(value), nil
+			}),
+			func(key string) bool { return key == "json" },
+		),
+		other,
+	)
+
+	data, err := Tag(`json:"a" yaml:"b"`, filler)
+	require.NoError(t, err)
+
+	assert.Equal(t, []TestTag{{Key: "json", Value: "A"}}, data)
+	assert.Equal(t, []TestTag{{Key: "json", Value: "a"}, {Key: "yaml", Value: "b"}}, other.Data())
+}
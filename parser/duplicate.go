@@ -0,0 +1,86 @@
+package parser
+
+// DuplicateKeysMode defines how the variants handle the duplicate keys.
+// A mode is a [DuplicateKeysPolicy] applied to all the keys.
+type DuplicateKeysMode int
+
+const (
+	// DuplicateKeysIgnore skips silently duplicate keys.
+	DuplicateKeysIgnore DuplicateKeysMode = iota
+
+	// DuplicateKeysDeny throws an error when duplicate keys are found.
+	DuplicateKeysDeny
+
+	// DuplicateKeysAllow NOT RECOMMENDED: this does not follow the struct tag conventions.
+	DuplicateKeysAllow
+
+	// DuplicateKeysLastWins replaces the value of the first key by the value of the duplicate key,
+	// the position of the first key is kept (like [github.com/fatih/structtag.Tags.Set]).
+	DuplicateKeysLastWins
+
+	// DuplicateKeysMerge appends the values of the duplicate key to the values of the first key
+	// (e.g., `json:"a" json:"omitempty"` is handled like `json:"a,omitempty"`).
+	DuplicateKeysMerge
+)
+
+// Mode returns the mode itself, for all the keys.
+func (m DuplicateKeysMode) Mode(string) DuplicateKeysMode {
+	return m
+}
+
+// DuplicateKeysPolicy defines how the duplicate keys are handled, key by key.
+// A [DuplicateKeysMode] applies the same mode to all the keys, [DuplicateKeysPerKey] a mode per key.
+type DuplicateKeysPolicy interface {
+	// Mode returns the mode of a key.
+	Mode(key string) DuplicateKeysMode
+}
+
+// DuplicateKeysPerKey is a [DuplicateKeysPolicy] with a mode per key.
+//
+//	parser.DuplicateKeysPerKey{
+//		Default: parser.DuplicateKeysIgnore,
+//		Keys:    map[string]parser.DuplicateKeysMode{"json": parser.DuplicateKeysDeny},
+//	}
+type DuplicateKeysPerKey struct {
+	// Default is the mode of the keys not in Keys.
+	Default DuplicateKeysMode
+
+	// Keys are the modes by key.
+	Keys map[string]DuplicateKeysMode
+}
+
+// Mode returns the mode of a key, or the default mode.
+func (p DuplicateKeysPerKey) Mode(key string) DuplicateKeysMode {
+	if mode, ok := p.Keys[key]; ok {
+		return mode
+	}
+
+	return p.Default
+}
+
+// DuplicateKeyMode returns the mode of a key, or def if the policy is nil.
+func DuplicateKeyMode(policy DuplicateKeysPolicy, key string, def DuplicateKeysMode) DuplicateKeysMode {
+	if policy == nil {
+		return def
+	}
+
+	return policy.Mode(key)
+}
+
+// MergeValues appends the raw value other to the raw value raw of a key
+// with the separator of the values of the key (comma by default, see [WithSeparator] and [ForKeys]).
+// This is the [DuplicateKeysMerge] behavior for the raw values.
+func MergeValues(key, raw, other string, options ...ValueOption) string {
+	cfg := newValueConfig(false)
+
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	if key != "" {
+		cfg.applyKeyOptions(key)
+	}
+
+	// The separator is a byte: string(cfg.Separator) would be the UTF-8 encoding of a rune.
+	return raw + string([]byte{cfg.Separator}) + other
+}
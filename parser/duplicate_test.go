@@ -0,0 +1,108 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDuplicateKeyMode(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		policy   DuplicateKeysPolicy
+		key      string
+		expected DuplicateKeysMode
+	}{
+		{
+			desc:     "nil policy",
+			key:      "json",
+			expected: DuplicateKeysAllow,
+		},
+		{
+			desc:     "mode",
+			policy:   DuplicateKeysMerge,
+			key:      "json",
+			expected: DuplicateKeysMerge,
+		},
+		{
+			desc: "per key",
+			policy: DuplicateKeysPerKey{
+				Default: DuplicateKeysIgnore,
+				Keys:    map[string]DuplicateKeysMode{"json": DuplicateKeysDeny},
+			},
+			key:      "json",
+			expected: DuplicateKeysDeny,
+		},
+		{
+			desc: "per key default",
+			policy: DuplicateKeysPerKey{
+				Default: DuplicateKeysLastWins,
+				Keys:    map[string]DuplicateKeysMode{"json": DuplicateKeysDeny},
+			},
+			key:      "yaml",
+			expected: DuplicateKeysLastWins,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			mode := DuplicateKeyMode(test.policy, test.key, DuplicateKeysAllow)
+
+			assert.Equal(t, test.expected, mode)
+		})
+	}
+}
+
+func TestMergeValues(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		key      string
+		options  []ValueOption
+		expected string
+	}{
+		{
+			desc:     "default separator",
+			key:      "gorm",
+			expected: "column:id,type:int",
+		},
+		{
+			desc:     "separator",
+			key:      "gorm",
+			options:  []ValueOption{WithSeparator(';')},
+			expected: "column:id;type:int",
+		},
+		{
+			desc:     "non-ASCII byte separator",
+			key:      "gorm",
+			options:  []ValueOption{WithSeparator(0xa7)},
+			expected: "column:id\xa7type:int",
+		},
+		{
+			desc:     "separator of the key",
+			key:      "gorm",
+			options:  []ValueOption{ForKeys([]string{"gorm"}, WithSeparator(';'))},
+			expected: "column:id;type:int",
+		},
+		{
+			desc:     "separator of another key",
+			key:      "json",
+			options:  []ValueOption{ForKeys([]string{"gorm"}, WithSeparator(';'))},
+			expected: "column:id,type:int",
+		},
+		{
+			desc:     "without key",
+			options:  []ValueOption{ForKeys([]string{"gorm"}, WithSeparator(';'))},
+			expected: "column:id,type:int",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, MergeValues(test.key, "column:id", "type:int", test.options...))
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrLimitExceeded is the common sentinel error of the limit kinds
+// ([KindTooManyPairs], [KindTagTooLong], [KindTooManyValues], [KindValueTooLong], [KindTooDeep]).
+var ErrLimitExceeded = errors.New("limit exceeded")
+
+// Sentinel errors, one per [ErrorKind], usable with [errors.Is].
+var (
+	ErrInvalidKeyChar      = errors.New("invalid key character")
+	ErrMissingColon        = errors.New("missing `:`")
+	ErrMissingOpeningQuote = errors.New("missing opening quote")
+	ErrMissingClosingQuote = errors.New("missing closing quote")
+	ErrBadEscape           = errors.New("invalid escape sequence")
+	ErrDuplicateKey        = errors.New("duplicate key")
+	ErrEmptyKey            = errors.New("empty key")
+	ErrNonASCIIKey         = errors.New("non-ASCII key")
+	ErrInvalidUTF8Value    = errors.New("invalid UTF-8 value")
+	ErrLeadingSpace        = errors.New("leading space")
+	ErrTrailingSpace       = errors.New("trailing space")
+	ErrMultipleSpaces      = errors.New("multiple spaces between pairs")
+	ErrMissingSeparator    = errors.New("missing space between pairs")
+	ErrDisallowedKeyChar   = errors.New("disallowed key character")
+	ErrTooManyPairs        = errors.New("too many pairs")
+	ErrTagTooLong          = errors.New("struct tag too long")
+	ErrTooManyValues       = errors.New("too many values")
+	ErrValueTooLong        = errors.New("value too long")
+	ErrTextAfterQuote      = errors.New("text after closing quote")
+	ErrUnbalancedBracket   = errors.New("unbalanced bracket")
+	ErrInvalidValue        = errors.New("invalid value")
+	ErrTooDeep             = errors.New("brackets nested too deep")
+)
+
+// ErrorKind is the kind of problem reported by a [SyntaxError].
+type ErrorKind int
+
+const (
+	// KindInvalidKeyChar a key starts with a space, a quote, or a control character.
+	KindInvalidKeyChar ErrorKind = iota + 1
+
+	// KindMissingColon a key is not followed by a colon.
+	KindMissingColon
+
+	// KindMissingOpeningQuote a colon is not followed by a quote.
+	KindMissingOpeningQuote
+
+	// KindMissingClosingQuote a quoted value is not terminated.
+	KindMissingClosingQuote
+
+	// KindBadEscape a quoted value contains an invalid escape sequence.
+	KindBadEscape
+
+	// KindDuplicateKey a key is used more than once.
+	KindDuplicateKey
+
+	// KindEmptyKey a colon is not preceded by a key.
+	KindEmptyKey
+
+	// KindNonASCIIKey a key contains a non-ASCII byte (strict mode).
+	KindNonASCIIKey
+
+	// KindInvalidUTF8Value a value is not valid UTF-8 (strict mode).
+	KindInvalidUTF8Value
+
+	// KindLeadingSpace the struct tag starts with a space (strict mode).
+	KindLeadingSpace
+
+	// KindTrailingSpace the struct tag ends with a space (strict mode).
+	KindTrailingSpace
+
+	// KindMultipleSpaces pairs are separated by more than one space (strict mode).
+	KindMultipleSpaces
+
+	// KindMissingSeparator pairs are not separated by a space (strict mode).
+	KindMissingSeparator
+
+	// KindDisallowedKeyChar a key contains a character rejected by [WithKeyChars].
+	KindDisallowedKeyChar
+
+	// KindTooManyPairs the struct tag contains more pairs than allowed by [WithMaxPairs].
+	KindTooManyPairs
+
+	// KindTagTooLong the struct tag is longer than allowed by [WithMaxLength].
+	KindTagTooLong
+
+	// KindTooManyValues the value contains more values than allowed by [WithMaxValues].
+	KindTooManyValues
+
+	// KindValueTooLong a value is longer than allowed by [WithMaxValueLength].
+	KindValueTooLong
+
+	// KindTextAfterQuote a single-quoted value is followed by text before the separator ([WithSingleQuotes]).
+	KindTextAfterQuote
+
+	// KindUnbalancedBracket a bracket is not closed, or not opened ([WithBrackets]).
+	KindUnbalancedBracket
+
+	// KindInvalidValue a value is rejected by the validation of [Validate].
+	KindInvalidValue
+
+	// KindTooDeep the brackets are nested deeper than allowed by [WithMaxDepth].
+	KindTooDeep
+)
+
+// String returns the description of the kind.
+func (k ErrorKind) String() string {
+	if err := k.sentinel(); err != nil {
+		return err.Error()
+	}
+
+	return fmt.Sprintf("ErrorKind(%d)", int(k))
+}
+
+func (k ErrorKind) sentinel() error {
+	switch k {
+	case KindInvalidKeyChar:
+		return ErrInvalidKeyChar
+	case KindMissingColon:
+		return ErrMissingColon
+	case KindMissingOpeningQuote:
+		return ErrMissingOpeningQuote
+	case KindMissingClosingQuote:
+		return ErrMissingClosingQuote
+	case KindBadEscape:
+		return ErrBadEscape
+	case KindDuplicateKey:
+		return ErrDuplicateKey
+	case KindEmptyKey:
+		return ErrEmptyKey
+	case KindNonASCIIKey:
+		return ErrNonASCIIKey
+	case KindInvalidUTF8Value:
+		return ErrInvalidUTF8Value
+	case KindLeadingSpace:
+		return ErrLeadingSpace
+	case KindTrailingSpace:
+		return ErrTrailingSpace
+	case KindMultipleSpaces:
+		return ErrMultipleSpaces
+	case KindMissingSeparator:
+		return ErrMissingSeparator
+	case KindDisallowedKeyChar:
+		return ErrDisallowedKeyChar
+	case KindTooManyPairs:
+		return ErrTooManyPairs
+	case KindTagTooLong:
+		return ErrTagTooLong
+	case KindTooManyValues:
+		return ErrTooManyValues
+	case KindValueTooLong:
+		return ErrValueTooLong
+	case KindTextAfterQuote:
+		return ErrTextAfterQuote
+	case KindUnbalancedBracket:
+		return ErrUnbalancedBracket
+	case KindInvalidValue:
+		return ErrInvalidValue
+	case KindTooDeep:
+		return ErrTooDeep
+	default:
+		return nil
+	}
+}
+
+// isValueKind returns true if the kind is related to the value of a pair.
+func (k ErrorKind) isValueKind() bool {
+	switch k {
+	case KindMissingOpeningQuote, KindMissingClosingQuote, KindBadEscape, KindInvalidUTF8Value,
+		KindTooManyValues, KindValueTooLong, KindTextAfterQuote, KindUnbalancedBracket,
+		KindInvalidValue, KindTooDeep:
+		return true
+	default:
+		return false
+	}
+}
+
+// isLimitKind returns true if the kind is related to a limit.
+func (k ErrorKind) isLimitKind() bool {
+	switch k {
+	case KindTooManyPairs, KindTagTooLong, KindTooManyValues, KindValueTooLong, KindTooDeep:
+		return true
+	default:
+		return false
+	}
+}
+
+// SyntaxError describes a problem found while parsing a struct tag.
+type SyntaxError struct {
+	// Kind is the kind of the problem.
+	Kind ErrorKind
+
+	// Tag is the struct tag being parsed (or the value for the errors returned by [Value]).
+	Tag string
+
+	// Offset is the byte offset of the problem in the struct tag.
+	Offset int
+
+	// Key is the key being parsed when the problem was found (can be empty).
+	Key string
+
+	// Err is the underlying error (can be nil).
+	Err error
+}
+
+// NewDuplicateKeyError creates a [SyntaxError] for a duplicate key.
+// The tag and the offset are set by [Tag] when the error is returned by a [Filler].
+func NewDuplicateKeyError(key string) *SyntaxError {
+	return &SyntaxError{Kind: KindDuplicateKey, Key: key}
+}
+
+func (e *SyntaxError) Error() string {
+	if e.Kind == KindDuplicateKey {
+		return fmt.Sprintf("duplicate key %q", e.Key)
+	}
+
+	category := "syntax"
+	if e.Kind.isValueKind() {
+		category = "value"
+	}
+
+	desc := e.Kind.String()
+	if e.Err != nil {
+		desc = e.Err.Error()
+	}
+
+	return fmt.Sprintf("invalid struct tag %s `%s`: %s", category, e.Tag, desc)
+}
+
+// Unwrap returns the sentinel error related to the kind, and the underlying error.
+func (e *SyntaxError) Unwrap() []error {
+	var errs []error
+
+	if err := e.Kind.sentinel(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if e.Kind.isLimitKind() {
+		errs = append(errs, ErrLimitExceeded)
+	}
+
+	if e.Err != nil {
+		errs = append(errs, e.Err)
+	}
+
+	return errs
+}
@@ -0,0 +1,22 @@
+package parser
+
+import "iter"
+
+// All returns an iterator over the key/value pairs of a struct tag, without building a collection.
+// The values are unquoted.
+//
+// The iteration stops at the first syntax error (or continues with [WithRecovery]),
+// and the error function returns the error of the last iteration (nil if the iteration was interrupted before the error).
+func All(tag string, options ...TagOption) (iter.Seq2[string, string], func() error) {
+	cfg := newTagConfig(options)
+
+	var err error
+
+	seq := func(yield func(string, string) bool) {
+		err = walk(tag, cfg, func(p pair) (bool, error) {
+			return yield(p.key, p.value), nil
+		})
+	}
+
+	return seq, func() error { return err }
+}
@@ -0,0 +1,109 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAll(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		tag      string
+		options  []TagOption
+		expected []TestTag
+		kinds    []ErrorKind
+	}{
+		{
+			desc: "empty",
+		},
+		{
+			desc: "multiple pairs",
+			tag:  `json:"a,omitempty" yaml:"b\tc"`,
+			expected: []TestTag{
+				{Key: "json", Value: "a,omitempty"},
+				{Key: "yaml", Value: "b\tc"},
+			},
+		},
+		{
+			desc: "duplicate keys",
+			tag:  `a:"1" a:"2"`,
+			expected: []TestTag{
+				{Key: "a", Value: "1"},
+				{Key: "a", Value: "2"},
+			},
+		},
+		{
+			desc: "syntax error",
+			tag:  `a:"1" b:2 c:"3"`,
+			expected: []TestTag{
+				{Key: "a", Value: "1"},
+			},
+			kinds: []ErrorKind{KindMissingOpeningQuote},
+		},
+		{
+			desc:    "recovery",
+			tag:     `a:"1" b:2 c:"3"`,
+			options: []TagOption{WithRecovery()},
+			expected: []TestTag{
+				{Key: "a", Value: "1"},
+				{Key: "c", Value: "3"},
+			},
+			kinds: []ErrorKind{KindMissingOpeningQuote},
+		},
+		{
+			desc:    "reflect compatibility",
+			tag:     `a:"1" b:"\x" c:2`,
+			options: []TagOption{WithReflectCompat()},
+			expected: []TestTag{
+				{Key: "a", Value: "1"},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			seq, errFn := All(test.tag, test.options...)
+
+			var tags []TestTag
+			for key, value := range seq {
+				tags = append(tags, TestTag{Key: key, Value: value})
+			}
+
+			assert.Equal(t, test.expected, tags)
+
+			err := errFn()
+			if len(test.kinds) == 0 {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.Error(t, err)
+
+			for _, kind := range test.kinds {
+				assert.ErrorIs(t, err, kind.sentinel())
+			}
+		})
+	}
+}
+
+func TestAll_break(t *testing.T) {
+	seq, errFn := All(`a:"1" b:"2" c:3`)
+
+	var keys []string
+
+	for key := range seq {
+		keys = append(keys, key)
+
+		if key == "b" {
+			break
+		}
+	}
+
+	assert.Equal(t, []string{"a", "b"}, keys)
+	require.NoError(t, errFn())
+}
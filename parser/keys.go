@@ -0,0 +1,53 @@
+package parser
+
+import "slices"
+
+// keyOptions are the value options of specific keys.
+type keyOptions struct {
+	keys    []string
+	options []ValueOption
+}
+
+// ForKeys applies the options only to the values of the given keys (see [KeyValue]).
+// The options are applied after the other options.
+//
+//	parser.ForKeys([]string{"default", "env-default"}, parser.WithEscapeComma())
+func ForKeys(keys []string, options ...ValueOption) ValueOption {
+	return func(cfg *valueConfig) {
+		cfg.Keys = append(cfg.Keys, keyOptions{keys: keys, options: options})
+	}
+}
+
+// KeyValue parses the value of a key, like [Value],
+// with the options of the key ([ForKeys]).
+func KeyValue(key, raw string, escapeComma bool, options ...ValueOption) ([]string, error) {
+	cfg := newValueConfig(escapeComma)
+
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	if key != "" {
+		cfg.applyKeyOptions(key)
+	}
+
+	return splitValue(raw, cfg)
+}
+
+// applyKeyOptions applies the options of a key.
+func (cfg *valueConfig) applyKeyOptions(key string) {
+	// The nested options of specific keys are ignored.
+	keys := cfg.Keys
+
+	for _, ko := range keys {
+		if !slices.Contains(ko.keys, key) {
+			continue
+		}
+
+		for _, opt := range ko.options {
+			opt(cfg)
+		}
+	}
+
+	cfg.Keys = keys
+}
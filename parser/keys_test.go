@@ -0,0 +1,83 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyValue(t *testing.T) {
+	escapeDefault := ForKeys([]string{"default", "env-default"}, WithEscapeComma())
+
+	testCases := []struct {
+		desc     string
+		key      string
+		raw      string
+		options  []ValueOption
+		expected []string
+	}{
+		{
+			desc:     "no key options",
+			key:      "default",
+			raw:      `a\,b,c`,
+			expected: []string{`a\`, "b", "c"},
+		},
+		{
+			desc:     "escaped key",
+			key:      "default",
+			raw:      `a\,b,c`,
+			options:  []ValueOption{escapeDefault},
+			expected: []string{`a\,b`, "c"},
+		},
+		{
+			desc:     "other escaped key",
+			key:      "env-default",
+			raw:      `a\,b,c`,
+			options:  []ValueOption{escapeDefault, WithUnescape()},
+			expected: []string{"a,b", "c"},
+		},
+		{
+			desc:     "not escaped key",
+			key:      "json",
+			raw:      `a\,b,c`,
+			options:  []ValueOption{escapeDefault},
+			expected: []string{`a\`, "b", "c"},
+		},
+		{
+			desc:     "key options after the other options",
+			key:      "gorm",
+			raw:      "a;b,c",
+			options:  []ValueOption{ForKeys([]string{"gorm"}, WithSeparator(';')), WithSeparator('|')},
+			expected: []string{"a", "b,c"},
+		},
+		{
+			desc:     "several key options",
+			key:      "gorm",
+			raw:      "a;b,c",
+			options:  []ValueOption{ForKeys([]string{"gorm"}, WithSeparator(';')), ForKeys([]string{"gorm"}, WithMaxValues(3))},
+			expected: []string{"a", "b,c"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			values, err := KeyValue(test.key, test.raw, false, test.options...)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, values)
+		})
+	}
+}
+
+func TestValue_keyOptions(t *testing.T) {
+	values, err := Value(`a\,b,c`, false, ForKeys([]string{"default"}, WithEscapeComma()))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{`a\`, "b", "c"}, values)
+
+	_, err = KeyValue("default", "a,b", false, ForKeys([]string{"default"}, WithMaxValues(1)))
+	require.ErrorIs(t, err, ErrTooManyValues)
+}
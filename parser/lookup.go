@@ -0,0 +1,45 @@
+package parser
+
+// Lookup returns the unquoted value associated with a key in a struct tag.
+// The scanning stops at the first matching key.
+//
+// The behavior is the same as [reflect.StructTag.Lookup]:
+// a syntax error, or an invalid value for the key, is handled as a missing key.
+// When the value has no escape sequence, the returned value is a substring of the struct tag (no allocation).
+func Lookup(tag, key string) (string, bool) {
+	s := scanner{tag: tag}
+
+	for {
+		p, ok, err := s.nextQuoted()
+		if err != nil || !ok {
+			return "", false
+		}
+
+		if p.key != key {
+			continue
+		}
+
+		if s.unquote(&p) != nil {
+			return "", false
+		}
+
+		return p.value, true
+	}
+}
+
+// LookupValues returns the values associated with a key in a struct tag.
+// The values are split like [Value].
+//
+// The behavior of the lookup is the same as [Lookup].
+// When the value has no escape sequence, only the slice of values is allocated.
+func LookupValues(tag, key string, escapeComma bool) ([]string, bool) {
+	value, ok := Lookup(tag, key)
+	if !ok {
+		return nil, false
+	}
+
+	// Without limits, the split cannot fail.
+	values, _ := splitValue(value, newValueConfig(escapeComma))
+
+	return values, true
+}
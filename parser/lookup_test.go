@@ -0,0 +1,210 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/fatih/structtag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookup(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		tag      string
+		key      string
+		expected string
+		found    bool
+	}{
+		{
+			desc: "empty",
+			key:  "json",
+		},
+		{
+			desc:     "first key",
+			tag:      `json:"a,omitempty" yaml:"b"`,
+			key:      "json",
+			expected: "a,omitempty",
+			found:    true,
+		},
+		{
+			desc:     "last key",
+			tag:      `json:"a,omitempty" yaml:"b"`,
+			key:      "yaml",
+			expected: "b",
+			found:    true,
+		},
+		{
+			desc: "missing key",
+			tag:  `json:"a,omitempty" yaml:"b"`,
+			key:  "toml",
+		},
+		{
+			desc:     "empty value",
+			tag:      `json:""`,
+			key:      "json",
+			expected: "",
+			found:    true,
+		},
+		{
+			desc:     "escaped value",
+			tag:      `json:"a\"b"`,
+			key:      "json",
+			expected: `a"b`,
+			found:    true,
+		},
+		{
+			desc:     "duplicate keys",
+			tag:      `json:"a" json:"b"`,
+			key:      "json",
+			expected: "a",
+			found:    true,
+		},
+		{
+			desc:     "syntax error after the key",
+			tag:      `json:"a" yaml:b`,
+			key:      "json",
+			expected: "a",
+			found:    true,
+		},
+		{
+			desc: "syntax error before the key",
+			tag:  `yaml:b json:"a"`,
+			key:  "json",
+		},
+		{
+			desc:     "invalid value of another key",
+			tag:      `yaml:"\x" json:"a"`,
+			key:      "json",
+			expected: "a",
+			found:    true,
+		},
+		{
+			desc: "invalid value",
+			tag:  `json:"\x" json:"a"`,
+			key:  "json",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			value, found := Lookup(test.tag, test.key)
+
+			assert.Equal(t, test.expected, value)
+			assert.Equal(t, test.found, found)
+
+			// Same behavior as reflect.
+			expected, expectedFound := reflect.StructTag(test.tag).Lookup(test.key)
+			assert.Equal(t, expected, value)
+			assert.Equal(t, expectedFound, found)
+		})
+	}
+}
+
+func TestLookupValues(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		tag         string
+		key         string
+		escapeComma bool
+		expected    []string
+		found       bool
+	}{
+		{
+			desc: "missing key",
+			tag:  `json:"a"`,
+			key:  "yaml",
+		},
+		{
+			desc:     "single value",
+			tag:      `json:"a"`,
+			key:      "json",
+			expected: []string{"a"},
+			found:    true,
+		},
+		{
+			desc:     "multiple values",
+			tag:      `json:"a,omitempty" yaml:"b"`,
+			key:      "json",
+			expected: []string{"a", "omitempty"},
+			found:    true,
+		},
+		{
+			desc:        "escaped comma",
+			tag:         `json:"a\\,b,c"`,
+			key:         "json",
+			escapeComma: true,
+			expected:    []string{`a\,b`, "c"},
+			found:       true,
+		},
+		{
+			desc:     "not escaped comma",
+			tag:      `json:"a\\,b,c"`,
+			key:      "json",
+			expected: []string{`a\`, "b", "c"},
+			found:    true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			values, found := LookupValues(test.tag, test.key, test.escapeComma)
+
+			assert.Equal(t, test.expected, values)
+			assert.Equal(t, test.found, found)
+		})
+	}
+}
+
+func TestLookup_allocs(t *testing.T) {
+	tag := `json:"name,omitempty" yaml:"name" xml:"name,attr"`
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = Lookup(tag, "xml")
+	})
+
+	assert.Zero(t, allocs)
+}
+
+const benchTag = `json:"name,omitempty" yaml:"name" xml:"name,attr" toml:"name" validate:"required"`
+
+func BenchmarkLookup(b *testing.B) {
+	b.ReportAllocs()
+
+	for b.Loop() {
+		_, _ = Lookup(benchTag, "xml")
+	}
+}
+
+func BenchmarkLookupValues(b *testing.B) {
+	b.ReportAllocs()
+
+	for b.Loop() {
+		_, _ = LookupValues(benchTag, "xml", false)
+	}
+}
+
+func BenchmarkLookup_reflect(b *testing.B) {
+	b.ReportAllocs()
+
+	for b.Loop() {
+		_, _ = reflect.StructTag(benchTag).Lookup("xml")
+	}
+}
+
+func BenchmarkLookup_fatih(b *testing.B) {
+	b.ReportAllocs()
+
+	for b.Loop() {
+		tags, err := structtag.Parse(benchTag)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		_, _ = tags.Get("xml")
+	}
+}
@@ -0,0 +1,166 @@
+package parser
+
+import "slices"
+
+// WhitespaceMode defines how the whitespace between pairs is handled.
+type WhitespaceMode int
+
+const (
+	// WhitespaceDefault skips any number of spaces between pairs (like [reflect.StructTag]).
+	WhitespaceDefault WhitespaceMode = iota
+
+	// WhitespaceStrict requires exactly one space between pairs, and no leading or trailing space.
+	WhitespaceStrict
+
+	// WhitespaceLenient skips any number of ASCII whitespace characters (space, tab, newline, etc.) between pairs.
+	WhitespaceLenient
+)
+
+// tagConfig for the struct tag parser.
+type tagConfig struct {
+	// Recovery continues the parsing after an error.
+	Recovery bool
+
+	// ReflectCompat mirrors the behavior of [reflect.StructTag.Lookup] on syntax errors.
+	ReflectCompat bool
+
+	// Strict requires ASCII keys and valid UTF-8 values.
+	Strict bool
+
+	// Whitespace defines how the whitespace between pairs is handled.
+	Whitespace WhitespaceMode
+
+	// MaxPairs is the maximum number of pairs (0 means no limit).
+	MaxPairs int
+
+	// MaxLength is the maximum length of the struct tag in bytes (0 means no limit).
+	MaxLength int
+
+	// KeyCharAllowed reports whether a character is allowed inside a key (nil means all).
+	KeyCharAllowed func(r rune) bool
+
+	// MultiByteControls handles the range [0x80, 0x9f] as control characters.
+	MultiByteControls bool
+
+	// Keys are the only keys sent to the [Filler] (nil means all).
+	Keys []string
+
+	// ExcludedKeys are the keys not sent to the [Filler].
+	ExcludedKeys []string
+}
+
+// TagOption configures [Tag].
+type TagOption func(*tagConfig)
+
+func newTagConfig(options []TagOption) tagConfig {
+	// Avoids the allocation of the configuration without options.
+	if len(options) == 0 {
+		return tagConfig{}
+	}
+
+	var cfg tagConfig
+
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return cfg
+}
+
+// WithRecovery continues the parsing after an error:
+// the parser resynchronizes at the next space-separated `key:` boundary,
+// the valid pairs are still sent to the [Filler],
+// and all the errors are returned joined with the partial data.
+func WithRecovery() TagOption {
+	return func(cfg *tagConfig) {
+		cfg.Recovery = true
+	}
+}
+
+// WithReflectCompat mirrors the behavior of [reflect.StructTag.Lookup]:
+// a syntax error ends the parsing without error, and the pairs already parsed are kept.
+// A pair with an invalid quoted value is ignored, and so are the next pairs with the same key.
+//
+// Combined with the first key wins behavior (e.g., DuplicateKeysIgnore),
+// the result is the same as [reflect.StructTag.Lookup] for every key.
+func WithReflectCompat() TagOption {
+	return func(cfg *tagConfig) {
+		cfg.ReflectCompat = true
+	}
+}
+
+// WithStrict enforces the canonical form of the struct tags (the conventions of the `go vet` structtag check):
+//   - exactly one ASCII space between pairs ([KindMultipleSpaces], [KindMissingSeparator]),
+//   - no leading or trailing space ([KindLeadingSpace], [KindTrailingSpace]),
+//   - non-empty ASCII keys ([KindEmptyKey], [KindNonASCIIKey]),
+//   - valid UTF-8 values ([KindInvalidUTF8Value]).
+func WithStrict() TagOption {
+	return func(cfg *tagConfig) {
+		cfg.Strict = true
+		cfg.Whitespace = WhitespaceStrict
+	}
+}
+
+// WithWhitespace defines how the whitespace between pairs is handled.
+func WithWhitespace(mode WhitespaceMode) TagOption {
+	return func(cfg *tagConfig) {
+		cfg.Whitespace = mode
+	}
+}
+
+// WithMaxPairs limits the number of pairs ([KindTooManyPairs]).
+func WithMaxPairs(n int) TagOption {
+	return func(cfg *tagConfig) {
+		cfg.MaxPairs = n
+	}
+}
+
+// WithMaxLength limits the length of the struct tag in bytes ([KindTagTooLong]).
+func WithMaxLength(n int) TagOption {
+	return func(cfg *tagConfig) {
+		cfg.MaxLength = n
+	}
+}
+
+// WithKeyChars restricts the characters allowed inside a key ([KindDisallowedKeyChar]).
+// For example, to reject keys containing `/` or `.`.
+func WithKeyChars(allowed func(r rune) bool) TagOption {
+	return func(cfg *tagConfig) {
+		cfg.KeyCharAllowed = allowed
+	}
+}
+
+// WithMultiByteControls handles the multi-byte control characters (U+0080 to U+009F) like the other control characters:
+// they are not allowed inside a key.
+// By default, like [reflect.StructTag], only the control characters [0x00, 0x1f] and 0x7f are handled.
+func WithMultiByteControls() TagOption {
+	return func(cfg *tagConfig) {
+		cfg.MultiByteControls = true
+	}
+}
+
+// WithKeys only sends the pairs with the given keys to the [Filler] (allowlist).
+// The other pairs are still syntax-checked, but their values are neither split nor stored.
+// Without keys, all the pairs are sent.
+func WithKeys(keys ...string) TagOption {
+	return func(cfg *tagConfig) {
+		cfg.Keys = append(cfg.Keys, keys...)
+	}
+}
+
+// WithoutKeys doesn't send the pairs with the given keys to the [Filler] (denylist).
+// The pairs are still syntax-checked, but their values are neither split nor stored.
+func WithoutKeys(keys ...string) TagOption {
+	return func(cfg *tagConfig) {
+		cfg.ExcludedKeys = append(cfg.ExcludedKeys, keys...)
+	}
+}
+
+// skipKey reports whether the pairs with the key are filtered out ([WithKeys], [WithoutKeys]).
+func (cfg *tagConfig) skipKey(key string) bool {
+	if cfg.Keys != nil && !slices.Contains(cfg.Keys, key) {
+		return true
+	}
+
+	return slices.Contains(cfg.ExcludedKeys, key)
+}
@@ -0,0 +1,57 @@
+package parser
+
+import (
+	"errors"
+	"sync"
+)
+
+// Resetter is implemented by the fillers that can be reused.
+type Resetter interface {
+	// Reset clears the data of the filler, and keeps the allocated memory when possible.
+	Reset()
+}
+
+// ResetFiller is a [Filler] that can be reused.
+type ResetFiller[T any] interface {
+	Filler[T]
+	Resetter
+}
+
+// FillerPool is a pool of reusable fillers.
+// It is safe for concurrent use.
+type FillerPool[T any] struct {
+	pool sync.Pool
+
+	options  []TagOption
+	recovery bool
+}
+
+// NewFillerPool creates a [FillerPool].
+// The options are used by [FillerPool.Parse] (see [Tag]).
+func NewFillerPool[T any](newFiller func() ResetFiller[T], options ...TagOption) *FillerPool[T] {
+	return &FillerPool[T]{
+		pool:     sync.Pool{New: func() any { return newFiller() }},
+		options:  options,
+		recovery: newTagConfig(options).Recovery,
+	}
+}
+
+// Parse parses a struct tag with a filler of the pool, and calls fn with the data.
+//
+// The data is only valid during the call of fn: it is reused by the next parsings.
+// With [WithRecovery], fn is called with the partial data, and the errors are joined.
+func (p *FillerPool[T]) Parse(tag string, fn func(data T) error) error {
+	filler := p.pool.Get().(ResetFiller[T])
+
+	defer func() {
+		filler.Reset()
+		p.pool.Put(filler)
+	}()
+
+	data, err := Tag(tag, filler, p.options...)
+	if err != nil && !p.recovery {
+		return err
+	}
+
+	return errors.Join(err, fn(data))
+}
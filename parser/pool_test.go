@@ -0,0 +1,150 @@
+package parser
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// resetFiller is a reusable [TestFiller].
+type resetFiller struct {
+	TestFiller
+}
+
+func (f *resetFiller) Reset() {
+	f.data = f.data[:0]
+}
+
+func newResetFiller() ResetFiller[[]TestTag] {
+	return &resetFiller{}
+}
+
+func TestFillerPool_Parse(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		options []TagOption
+		tags    []string
+	}{
+		{
+			desc: "reused filler",
+			tags: []string{`a:"1" b:"2"`, `c:"3"`, `a:"4"`},
+		},
+		{
+			desc: "empty tag after pairs",
+			tags: []string{`a:"1" b:"2"`, ``, `  `},
+		},
+		{
+			desc:    "tag options",
+			options: []TagOption{WithKeys("a", "c")},
+			tags:    []string{`a:"1" b:"2"`, `b:"3" c:"4"`},
+		},
+		{
+			desc:    "whitespace",
+			options: []TagOption{WithWhitespace(WhitespaceLenient)},
+			tags:    []string{"a:\"1\"\tb:\"2\"", `c:"3"`},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			pool := NewFillerPool(newResetFiller, test.options...)
+
+			for _, tag := range test.tags {
+				expected, err := Tag(tag, &TestFiller{}, test.options...)
+				require.NoError(t, err)
+
+				err = pool.Parse(tag, func(data []TestTag) error {
+					// The data of a reused filler is empty, not nil.
+					if len(expected) == 0 {
+						assert.Empty(t, data)
+					} else {
+						assert.Equal(t, expected, data)
+					}
+
+					return nil
+				})
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFillerPool_Parse_error(t *testing.T) {
+	pool := NewFillerPool(newResetFiller)
+
+	var called bool
+
+	err := pool.Parse(`a:1`, func(_ []TestTag) error {
+		called = true
+
+		return nil
+	})
+	require.ErrorIs(t, err, ErrMissingOpeningQuote)
+
+	assert.False(t, called)
+}
+
+func TestFillerPool_Parse_callback_error(t *testing.T) {
+	pool := NewFillerPool(newResetFiller)
+
+	errCallback := errors.New("callback")
+
+	err := pool.Parse(`a:"1"`, func(_ []TestTag) error {
+		return errCallback
+	})
+	require.ErrorIs(t, err, errCallback)
+}
+
+func TestFillerPool_Parse_recovery(t *testing.T) {
+	pool := NewFillerPool(newResetFiller, WithRecovery())
+
+	err := pool.Parse(`a:"1" b:2 c:"3"`, func(data []TestTag) error {
+		assert.Equal(t, []TestTag{{Key: "a", Value: "1"}, {Key: "c", Value: "3"}}, data)
+
+		return nil
+	})
+	require.ErrorIs(t, err, ErrMissingOpeningQuote)
+}
+
+func TestFillerPool_Parse_concurrency(t *testing.T) {
+	pool := NewFillerPool(newResetFiller)
+
+	var wg sync.WaitGroup
+
+	for range 20 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for range 100 {
+				err := pool.Parse(`a:"1" b:"2"`, func(data []TestTag) error {
+					assert.Equal(t, []TestTag{{Key: "a", Value: "1"}, {Key: "b", Value: "2"}}, data)
+
+					return nil
+				})
+				assert.NoError(t, err)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func BenchmarkFillerPool_Parse(b *testing.B) {
+	pool := NewFillerPool(newResetFiller)
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		err := pool.Parse(benchTag, func(_ []TestTag) error { return nil })
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
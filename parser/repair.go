@@ -0,0 +1,226 @@
+package parser
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Fix is a change applied by [Repair].
+type Fix struct {
+	// Kind is the kind of the fixed problem.
+	Kind ErrorKind
+
+	// Span is the location of the replaced text inside the original struct tag.
+	Span Span
+
+	// Replacement is the text that replaces the span.
+	Replacement string
+}
+
+// Repair fixes the common mistakes of a struct tag, and returns the canonical struct tag with the applied fixes.
+//
+// The fixed mistakes are:
+//   - unquoted values (`json:name`): [KindMissingOpeningQuote],
+//   - `=` instead of `:` (`json="name"`): [KindMissingColon], only when the key is not followed by a `:`,
+//   - non-space separators (`json:"a",yaml:"b"`, `json:"a"\tyaml:"b"`): [KindMissingSeparator],
+//   - non-canonical whitespace: [KindLeadingSpace], [KindTrailingSpace], [KindMultipleSpaces], [KindMissingSeparator].
+//
+// A struct tag valid with [WithStrict] is returned unchanged, without fixes.
+// The other problems cannot be fixed and are returned as [*SyntaxError].
+func Repair(tag string) (string, []Fix, error) {
+	r := &repairer{tag: tag}
+
+	for {
+		done := r.separator()
+		if done {
+			break
+		}
+
+		if err := r.pair(); err != nil {
+			return "", nil, err
+		}
+	}
+
+	return r.b.String(), r.fixes, nil
+}
+
+type repairer struct {
+	tag string
+
+	// pos is the offset of the next byte to read.
+	pos int
+
+	// pairs is the number of pairs written.
+	pairs int
+
+	b     strings.Builder
+	fixes []Fix
+}
+
+// separator reads the separator before the next pair, and writes the canonical separator.
+// It returns true at the end of the struct tag.
+func (r *repairer) separator() bool {
+	start := r.pos
+
+	// whitespaces is the number of ASCII whitespace characters (spaces included).
+	whitespaces, spaces := 0, 0
+
+scan:
+	for r.pos < len(r.tag) {
+		c := r.tag[r.pos]
+
+		// A comma or a semicolon is a separator only right after a value:
+		// after a whitespace, this is a valid key character.
+		switch {
+		case c == ' ':
+			whitespaces++
+			spaces++
+
+		case isASCIISpace(c):
+			whitespaces++
+
+		case r.pairs == 0 || whitespaces > 0 || (c != ',' && c != ';'):
+			break scan
+		}
+
+		r.pos++
+	}
+
+	sep := r.tag[start:r.pos]
+
+	switch {
+	case r.pos >= len(r.tag):
+		kind := KindTrailingSpace
+		if r.pairs == 0 {
+			kind = KindLeadingSpace
+		}
+
+		r.fix(kind, start, "")
+
+		return true
+
+	case r.pairs == 0:
+		r.fix(KindLeadingSpace, start, "")
+
+	case sep == " ":
+		r.b.WriteString(" ")
+
+	case spaces == len(sep) && spaces > 1:
+		r.b.WriteString(" ")
+		r.fixAt(KindMultipleSpaces, start, r.pos, " ")
+
+	default:
+		r.b.WriteString(" ")
+		r.fixAt(KindMissingSeparator, start, r.pos, " ")
+	}
+
+	return false
+}
+
+// pair reads a pair, and writes the fixed pair.
+// The pair is read by the [scanner] in strict mode: the repaired struct tag follows [WithStrict].
+func (r *repairer) pair() error {
+	start := r.pos
+
+	end := start
+	for end < len(r.tag) && isKeyChar(r.tag[end]) {
+		end++
+	}
+
+	tag := r.tag
+
+	// `=` is a valid key character: it is replaced only if the key is not followed by a colon.
+	if end >= len(tag) || tag[end] != ':' {
+		if eq := strings.IndexByte(tag[start:end], '='); eq > 0 {
+			colon := start + eq
+
+			r.fixAt(KindMissingColon, colon, colon+1, ":")
+
+			// The offsets are the same in the fixed struct tag.
+			tag = tag[:colon] + ":" + tag[colon+1:]
+		}
+	}
+
+	s := &scanner{tag: tag, cfg: tagConfig{Strict: true}, pos: start}
+
+	p, _, err := s.next()
+
+	var syntaxErr *SyntaxError
+
+	switch {
+	case err == nil:
+		r.b.WriteString(tag[start:s.pos])
+		r.pos = s.pos
+		r.pairs++
+
+		return nil
+
+	case errors.As(err, &syntaxErr) && syntaxErr.Kind == KindMissingOpeningQuote:
+		r.b.WriteString(syntaxErr.Key)
+		r.b.WriteString(":")
+		r.pos = syntaxErr.Offset
+		r.pairs++
+
+		r.unquotedValue()
+
+		return nil
+
+	case errors.As(err, &syntaxErr):
+		// The errors are reported on the original struct tag.
+		syntaxErr.Tag = r.tag
+
+		if syntaxErr.Key == "" {
+			syntaxErr.Key = p.key
+		}
+
+		return syntaxErr
+
+	default:
+		return err
+	}
+}
+
+// unquotedValue reads an unquoted value (until the next whitespace), and writes it quoted.
+func (r *repairer) unquotedValue() {
+	start := r.pos
+
+	for r.pos < len(r.tag) && !isASCIISpace(r.tag[r.pos]) {
+		r.pos++
+	}
+
+	value := r.tag[start:r.pos]
+
+	// Only the opening quote is missing.
+	if strings.HasSuffix(value, `"`) && utf8.ValidString(value) {
+		if _, err := strconv.Unquote(`"` + value); err == nil {
+			r.b.WriteString(`"` + value)
+			r.fixAt(KindMissingOpeningQuote, start, start, `"`)
+
+			return
+		}
+	}
+
+	quoted := strconv.Quote(value)
+
+	r.b.WriteString(quoted)
+	r.fixAt(KindMissingOpeningQuote, start, r.pos, quoted)
+}
+
+// fix records a fix from start to the current position, if the span is not empty.
+func (r *repairer) fix(kind ErrorKind, start int, replacement string) {
+	if start == r.pos {
+		return
+	}
+
+	r.fixAt(kind, start, r.pos, replacement)
+}
+
+func (r *repairer) fixAt(kind ErrorKind, start, end int, replacement string) {
+	r.fixes = append(r.fixes, Fix{
+		Kind:        kind,
+		Span:        Span{Start: start, End: end},
+		Replacement: replacement,
+	})
+}
@@ -0,0 +1,295 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepair(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		tag      string
+		expected string
+		fixes    []Fix
+	}{
+		{
+			desc: "empty",
+		},
+		{
+			desc:     "valid",
+			tag:      `json:"a" yaml:"b,omitempty"`,
+			expected: `json:"a" yaml:"b,omitempty"`,
+		},
+		{
+			desc:     "unquoted value",
+			tag:      `json:name`,
+			expected: `json:"name"`,
+			fixes: []Fix{
+				{Kind: KindMissingOpeningQuote, Span: Span{Start: 5, End: 9}, Replacement: `"name"`},
+			},
+		},
+		{
+			desc:     "unquoted value with a quote",
+			tag:      `json:a"b`,
+			expected: `json:"a\"b"`,
+			fixes: []Fix{
+				{Kind: KindMissingOpeningQuote, Span: Span{Start: 5, End: 8}, Replacement: `"a\"b"`},
+			},
+		},
+		{
+			desc:     "missing value",
+			tag:      `json: yaml:"b"`,
+			expected: `json:"" yaml:"b"`,
+			fixes: []Fix{
+				{Kind: KindMissingOpeningQuote, Span: Span{Start: 5, End: 5}, Replacement: `""`},
+			},
+		},
+		{
+			desc:     "missing opening quote",
+			tag:      `json:name,omitempty"`,
+			expected: `json:"name,omitempty"`,
+			fixes: []Fix{
+				{Kind: KindMissingOpeningQuote, Span: Span{Start: 5, End: 5}, Replacement: `"`},
+			},
+		},
+		{
+			desc:     "equal sign",
+			tag:      `json="name"`,
+			expected: `json:"name"`,
+			fixes: []Fix{
+				{Kind: KindMissingColon, Span: Span{Start: 4, End: 5}, Replacement: ":"},
+			},
+		},
+		{
+			desc:     "equal sign and unquoted value",
+			tag:      `json=name`,
+			expected: `json:"name"`,
+			fixes: []Fix{
+				{Kind: KindMissingColon, Span: Span{Start: 4, End: 5}, Replacement: ":"},
+				{Kind: KindMissingOpeningQuote, Span: Span{Start: 5, End: 9}, Replacement: `"name"`},
+			},
+		},
+		{
+			desc:     "comma separator",
+			tag:      `json:"a",yaml:"b"`,
+			expected: `json:"a" yaml:"b"`,
+			fixes: []Fix{
+				{Kind: KindMissingSeparator, Span: Span{Start: 8, End: 9}, Replacement: " "},
+			},
+		},
+		{
+			desc:     "semicolon and spaces separator",
+			tag:      `json:"a"; yaml:"b"`,
+			expected: `json:"a" yaml:"b"`,
+			fixes: []Fix{
+				{Kind: KindMissingSeparator, Span: Span{Start: 8, End: 10}, Replacement: " "},
+			},
+		},
+		{
+			desc:     "no separator",
+			tag:      `json:"a"yaml:"b"`,
+			expected: `json:"a" yaml:"b"`,
+			fixes: []Fix{
+				{Kind: KindMissingSeparator, Span: Span{Start: 8, End: 8}, Replacement: " "},
+			},
+		},
+		{
+			desc:     "whitespace",
+			tag:      `  json:"a"   yaml:"b" `,
+			expected: `json:"a" yaml:"b"`,
+			fixes: []Fix{
+				{Kind: KindLeadingSpace, Span: Span{Start: 0, End: 2}, Replacement: ""},
+				{Kind: KindMultipleSpaces, Span: Span{Start: 10, End: 13}, Replacement: " "},
+				{Kind: KindTrailingSpace, Span: Span{Start: 21, End: 22}, Replacement: ""},
+			},
+		},
+		{
+			desc:     "only spaces",
+			tag:      `   `,
+			expected: ``,
+			fixes: []Fix{
+				{Kind: KindLeadingSpace, Span: Span{Start: 0, End: 3}, Replacement: ""},
+			},
+		},
+		{
+			desc:     "tab separator",
+			tag:      "json:\"a\"\tyaml:\"b\"",
+			expected: `json:"a" yaml:"b"`,
+			fixes: []Fix{
+				{Kind: KindMissingSeparator, Span: Span{Start: 8, End: 9}, Replacement: " "},
+			},
+		},
+		{
+			desc:     "unquoted value before a tab",
+			tag:      "json:name\tyaml:\"b\"",
+			expected: `json:"name" yaml:"b"`,
+			fixes: []Fix{
+				{Kind: KindMissingOpeningQuote, Span: Span{Start: 5, End: 9}, Replacement: `"name"`},
+				{Kind: KindMissingSeparator, Span: Span{Start: 9, End: 10}, Replacement: " "},
+			},
+		},
+		{
+			desc:     "mixed whitespace separator",
+			tag:      "\tjson:\"a\" \r\n yaml:\"b\"\n",
+			expected: `json:"a" yaml:"b"`,
+			fixes: []Fix{
+				{Kind: KindLeadingSpace, Span: Span{Start: 0, End: 1}},
+				{Kind: KindMissingSeparator, Span: Span{Start: 9, End: 13}, Replacement: " "},
+				{Kind: KindTrailingSpace, Span: Span{Start: 21, End: 22}},
+			},
+		},
+		{
+			desc:     "multiple fixes",
+			tag:      `json=name yaml:b`,
+			expected: `json:"name" yaml:"b"`,
+			fixes: []Fix{
+				{Kind: KindMissingColon, Span: Span{Start: 4, End: 5}, Replacement: ":"},
+				{Kind: KindMissingOpeningQuote, Span: Span{Start: 5, End: 9}, Replacement: `"name"`},
+				{Kind: KindMissingOpeningQuote, Span: Span{Start: 15, End: 16}, Replacement: `"b"`},
+			},
+		},
+		{
+			desc:     "equal sign in a key",
+			tag:      `a=b:"x"`,
+			expected: `a=b:"x"`,
+		},
+		{
+			desc:     "equal sign at the end of a key",
+			tag:      `a=:","`,
+			expected: `a=:","`,
+		},
+		{
+			desc:     "equal sign in a key with an unquoted value",
+			tag:      `json=name,yaml:b`,
+			expected: `json=name,yaml:"b"`,
+			fixes: []Fix{
+				{Kind: KindMissingOpeningQuote, Span: Span{Start: 15, End: 16}, Replacement: `"b"`},
+			},
+		},
+		{
+			desc:     "comma at the start of a key",
+			tag:      `a:"b" ,c:"d"`,
+			expected: `a:"b" ,c:"d"`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			tag, fixes, err := Repair(test.tag)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, tag)
+			assert.Equal(t, test.fixes, fixes)
+
+			_, err = Tag(tag, &TestFiller{}, WithStrict())
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestRepair_error(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		tag    string
+		kind   ErrorKind
+		offset int
+		key    string
+	}{
+		{
+			desc:   "empty key",
+			tag:    `:"a"`,
+			kind:   KindEmptyKey,
+			offset: 0,
+		},
+		{
+			desc:   "invalid key character",
+			tag:    `"a"`,
+			kind:   KindInvalidKeyChar,
+			offset: 0,
+		},
+		{
+			desc:   "missing colon",
+			tag:    `json "a"`,
+			kind:   KindMissingColon,
+			offset: 4,
+			key:    "json",
+		},
+		{
+			desc:   "non-ASCII key",
+			tag:    "béx=",
+			kind:   KindNonASCIIKey,
+			offset: 1,
+			key:    "b",
+		},
+		{
+			desc:   "missing closing quote",
+			tag:    `json:"a`,
+			kind:   KindMissingClosingQuote,
+			offset: 5,
+			key:    "json",
+		},
+		{
+			desc:   "bad escape",
+			tag:    `json:"\x"`,
+			kind:   KindBadEscape,
+			offset: 5,
+			key:    "json",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			tag, fixes, err := Repair(test.tag)
+
+			var synErr *SyntaxError
+			require.ErrorAs(t, err, &synErr)
+
+			assert.Equal(t, test.kind, synErr.Kind)
+			assert.Equal(t, test.offset, synErr.Offset)
+			assert.Equal(t, test.key, synErr.Key)
+
+			assert.Empty(t, tag)
+			assert.Empty(t, fixes)
+		})
+	}
+}
+
+func FuzzRepair(f *testing.F) {
+	f.Add(`json:"a" yaml:"b,omitempty"`)
+	f.Add(`json:name`)
+	f.Add(`json="name"`)
+	f.Add(`a=:","`)
+	f.Add(`a=b:"x"`)
+	f.Add(`json:"a",yaml:"b"`)
+	f.Add(`  json:"a"   yaml:"b"  `)
+	f.Add("json:\"a\"\tyaml:\"b\"")
+	f.Add("béx=")
+	f.Add("0=\xb6\"")
+
+	f.Fuzz(func(t *testing.T, tag string) {
+		repaired, fixes, err := Repair(tag)
+
+		_, strictErr := Tag(tag, &TestFiller{}, WithStrict())
+		if strictErr == nil {
+			// A valid struct tag is unchanged.
+			require.NoError(t, err)
+			assert.Equal(t, tag, repaired)
+			assert.Empty(t, fixes)
+
+			return
+		}
+
+		if err != nil {
+			return
+		}
+
+		_, err = Tag(repaired, &TestFiller{}, WithStrict())
+		require.NoError(t, err, "repaired: %q", repaired)
+	})
+}
@@ -0,0 +1,330 @@
+// Copyright 2009 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package parser
+
+import (
+	"strconv"
+	"unicode/utf8"
+)
+
+// pair is a key/value pair found by the [scanner].
+type pair struct {
+	key string
+
+	// qvalue is the quoted value.
+	qvalue string
+
+	// value is the unquoted value.
+	value string
+
+	// keyStart is the offset of the key.
+	keyStart int
+
+	// valueStart is the offset of the opening quote.
+	valueStart int
+
+	// plain is true when the quoted value contains no escape sequence:
+	// the unquoted value is the quoted value without the quotes.
+	plain bool
+}
+
+// spans returns the location of the pair.
+func (p pair) spans() Spans {
+	valueEnd := p.valueStart + len(p.qvalue)
+
+	return Spans{
+		Key:         Span{Start: p.keyStart, End: p.keyStart + len(p.key)},
+		QuotedValue: Span{Start: p.valueStart, End: valueEnd},
+		Value:       Span{Start: p.valueStart + 1, End: valueEnd - 1},
+	}
+}
+
+// scanner scans the key/value pairs of a struct tag.
+//
+// Based on https://github.com/golang/go/blob/411c250d64304033181c46413a6e9381e8fe9b82/src/reflect/type.go#L1030-L1108
+type scanner struct {
+	tag string
+
+	cfg tagConfig
+
+	// pos is the offset of the next byte to scan.
+	pos int
+
+	// pairs is the number of pairs found.
+	pairs int
+
+	// spacesChecked is true when the spaces before the next pair are already checked.
+	spacesChecked bool
+
+	// resyncFrom is the offset used to recover from the last error, -1 if no resynchronization is needed.
+	resyncFrom int
+}
+
+func newScanner(tag string, cfg tagConfig) *scanner {
+	return &scanner{tag: tag, cfg: cfg}
+}
+
+// next returns the next pair.
+// It returns false when the end of the tag is reached.
+// On a structural error, it returns false.
+// On an invalid value, it returns the pair (without value) and true,
+// and the position moves after the pair.
+func (s *scanner) next() (pair, bool, error) {
+	p, ok, err := s.nextQuoted()
+	if err != nil || !ok {
+		return p, ok, err
+	}
+
+	if err := s.unquote(&p); err != nil {
+		return p, true, err
+	}
+
+	return p, true, nil
+}
+
+// nextQuoted is like next, but the value is not unquoted (see unquote).
+//
+//nolint:gocyclo // Based on reflect.StructTag.Lookup.
+func (s *scanner) nextQuoted() (pair, bool, error) {
+	if s.pos == 0 && s.cfg.MaxLength > 0 && len(s.tag) > s.cfg.MaxLength {
+		return pair{}, false, s.abort(&SyntaxError{Kind: KindTagTooLong, Tag: s.tag, Offset: s.cfg.MaxLength})
+	}
+
+	// Skip leading space.
+	start := s.pos
+	for s.pos < len(s.tag) && s.isSpace(s.tag[s.pos]) {
+		s.pos++
+	}
+
+	if s.cfg.Whitespace == WhitespaceStrict && !s.spacesChecked {
+		s.spacesChecked = true
+
+		if err := s.checkSpaces(start); err != nil {
+			s.resyncFrom = -1
+
+			return pair{}, false, err
+		}
+	}
+
+	if s.pos >= len(s.tag) {
+		return pair{}, false, nil
+	}
+
+	s.resyncFrom = s.pos
+
+	tag := s.tag[s.pos:]
+
+	// Scan to colon. A space, a quote or a control character is a syntax error.
+	// Strictly speaking, control chars include the range [0x7f, 0x9f], not just
+	// [0x00, 0x1f], but by default, we ignore the multi-byte control characters
+	// as it is simpler to inspect the tag's bytes than the tag's runes (see [WithMultiByteControls]).
+	i := 0
+	for i < len(tag) && isKeyChar(tag[i]) && !s.isMultiByteControl(tag[i:]) {
+		if s.cfg.Strict && tag[i] >= utf8.RuneSelf {
+			return pair{}, false, &SyntaxError{Kind: KindNonASCIIKey, Tag: s.tag, Offset: s.pos + i, Key: tag[:i]}
+		}
+
+		i++
+	}
+
+	switch {
+	case i == 0 && tag[0] == ':':
+		return pair{}, false, &SyntaxError{Kind: KindEmptyKey, Tag: s.tag, Offset: s.pos}
+
+	case i == 0:
+		return pair{}, false, &SyntaxError{Kind: KindInvalidKeyChar, Tag: s.tag, Offset: s.pos}
+
+	case i >= len(tag) || tag[i] != ':':
+		return pair{}, false, &SyntaxError{Kind: KindMissingColon, Tag: s.tag, Offset: s.pos + i, Key: tag[:i]}
+
+	case i+1 >= len(tag) || tag[i+1] != '"':
+		return pair{}, false, &SyntaxError{Kind: KindMissingOpeningQuote, Tag: s.tag, Offset: s.pos + i + 1, Key: tag[:i]}
+	}
+
+	if err := s.checkKeyChars(tag[:i]); err != nil {
+		return pair{}, false, err
+	}
+
+	if s.cfg.MaxPairs > 0 && s.pairs >= s.cfg.MaxPairs {
+		return pair{}, false, s.abort(&SyntaxError{Kind: KindTooManyPairs, Tag: s.tag, Offset: s.pos, Key: tag[:i]})
+	}
+
+	p := pair{
+		key:        tag[:i],
+		keyStart:   s.pos,
+		valueStart: s.pos + i + 1,
+	}
+
+	tag = tag[i+1:]
+
+	// Scan quoted string to find value.
+	// A valid UTF-8 value without backslash and newline can be used without unquoting.
+	plain, ascii := true, true
+
+	i = 1
+	for i < len(tag) && tag[i] != '"' {
+		switch {
+		case tag[i] == '\\':
+			plain = false
+			i++
+
+		case tag[i] == '\n':
+			plain = false
+
+		case tag[i] >= utf8.RuneSelf:
+			ascii = false
+		}
+
+		i++
+	}
+
+	if i >= len(tag) {
+		return pair{}, false, &SyntaxError{Kind: KindMissingClosingQuote, Tag: s.tag, Offset: p.valueStart, Key: p.key}
+	}
+
+	p.qvalue = tag[:i+1]
+	p.plain = plain && (ascii || utf8.ValidString(tag[1:i]))
+
+	s.pos = p.valueStart + i + 1
+	s.pairs++
+	s.spacesChecked = false
+
+	if s.cfg.Strict && !utf8.ValidString(p.qvalue) {
+		return p, true, &SyntaxError{Kind: KindInvalidUTF8Value, Tag: s.tag, Offset: p.valueStart, Key: p.key}
+	}
+
+	return p, true, nil
+}
+
+// unquote sets the unquoted value of a pair returned by nextQuoted.
+func (s *scanner) unquote(p *pair) error {
+	// Fast path: same result as strconv.Unquote, without the validation of the value.
+	if p.plain {
+		p.value = p.qvalue[1 : len(p.qvalue)-1]
+
+		return nil
+	}
+
+	value, err := strconv.Unquote(p.qvalue)
+	if err != nil {
+		return &SyntaxError{Kind: KindBadEscape, Tag: s.tag, Offset: p.valueStart, Key: p.key, Err: err}
+	}
+
+	p.value = value
+
+	return nil
+}
+
+// checkSpaces checks that the spaces between start and the current position follow the canonical form:
+// exactly one space between pairs, and no leading or trailing space.
+func (s *scanner) checkSpaces(start int) error {
+	count := s.pos - start
+
+	switch {
+	case start == 0 && count > 0:
+		return &SyntaxError{Kind: KindLeadingSpace, Tag: s.tag, Offset: start}
+
+	case start == 0:
+		return nil
+
+	case s.pos >= len(s.tag) && count > 0:
+		return &SyntaxError{Kind: KindTrailingSpace, Tag: s.tag, Offset: start}
+
+	case s.pos < len(s.tag) && count == 0:
+		return &SyntaxError{Kind: KindMissingSeparator, Tag: s.tag, Offset: start}
+
+	case count > 1:
+		return &SyntaxError{Kind: KindMultipleSpaces, Tag: s.tag, Offset: start}
+
+	default:
+		return nil
+	}
+}
+
+// checkKeyChars checks the characters of a key with [WithKeyChars].
+func (s *scanner) checkKeyChars(key string) error {
+	if s.cfg.KeyCharAllowed == nil {
+		return nil
+	}
+
+	for i, r := range key {
+		if !s.cfg.KeyCharAllowed(r) {
+			return &SyntaxError{Kind: KindDisallowedKeyChar, Tag: s.tag, Offset: s.pos + i, Key: key}
+		}
+	}
+
+	return nil
+}
+
+// abort stops the scanning: the error cannot be recovered.
+func (s *scanner) abort(err *SyntaxError) error {
+	s.pos = len(s.tag)
+	s.resyncFrom = -1
+
+	return err
+}
+
+// isSpace reports whether a character separates pairs.
+func (s *scanner) isSpace(c byte) bool {
+	if s.cfg.Whitespace == WhitespaceLenient {
+		return isASCIISpace(c)
+	}
+
+	return c == ' '
+}
+
+// isASCIISpace reports whether a character is an ASCII whitespace character.
+func isASCIISpace(c byte) bool {
+	switch c {
+	case ' ', '\t', '\n', '\v', '\f', '\r':
+		return true
+	default:
+		return false
+	}
+}
+
+// isMultiByteControl reports whether the tag starts with a multi-byte control character (U+0080 to U+009F),
+// when [WithMultiByteControls] is used.
+func (s *scanner) isMultiByteControl(tag string) bool {
+	return s.cfg.MultiByteControls && len(tag) > 1 && tag[0] == 0xc2 && tag[1] >= 0x80 && tag[1] <= 0x9f
+}
+
+// recover moves the position after an error returned by next, to continue the scanning.
+func (s *scanner) recover() {
+	if s.resyncFrom < 0 {
+		return
+	}
+
+	s.pos = s.resyncFrom
+
+	s.resync()
+}
+
+// resync moves the position to the next space-separated `key:` boundary,
+// or to the end of the tag if there is none.
+func (s *scanner) resync() {
+	for i := s.pos + 1; i < len(s.tag); i++ {
+		if !s.isSpace(s.tag[i-1]) || s.isSpace(s.tag[i]) {
+			continue
+		}
+
+		j := i
+		for j < len(s.tag) && isKeyChar(s.tag[j]) {
+			j++
+		}
+
+		if j > i && j < len(s.tag) && s.tag[j] == ':' {
+			s.pos = i
+
+			return
+		}
+	}
+
+	s.pos = len(s.tag)
+}
+
+func isKeyChar(c byte) bool {
+	return c > ' ' && c != ':' && c != '"' && c != 0x7f
+}
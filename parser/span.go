@@ -0,0 +1,26 @@
+package parser
+
+// Span is a range of bytes `[Start, End)` inside a struct tag.
+type Span struct {
+	Start int
+	End   int
+}
+
+// Spans are the locations of a key/value pair inside a struct tag.
+type Spans struct {
+	// Key is the location of the key.
+	Key Span
+
+	// QuotedValue is the location of the value, including the quotes.
+	QuotedValue Span
+
+	// Value is the location of the value, without the quotes.
+	Value Span
+}
+
+// SpanFiller is an optional interface implemented by a [Filler] that needs the location of the pairs.
+// When a [Filler] implements it, [Tag] calls FillSpans instead of Fill.
+type SpanFiller interface {
+	// FillSpans fills the data from a struct tag, with the location of the key and the value.
+	FillSpans(key, value string, spans Spans) error
+}
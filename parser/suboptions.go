@@ -0,0 +1,45 @@
+package parser
+
+import "strings"
+
+// OptionPair is an option of a tag value (e.g., `min=1`, `column:id`, `omitempty`).
+type OptionPair struct {
+	// Name is the name of the option.
+	Name string
+
+	// Value is the value of the option (empty for a flag).
+	Value string
+
+	// Flag is true when the option has no value (e.g., `omitempty`).
+	Flag bool
+}
+
+// Options parses the values returned by [Value] as options.
+// Each value is split on the first separator (e.g., `=` or `:`) into a name and a value,
+// and a value without separator is a flag.
+// The spaces around the names and the values are removed (e.g., `name=x, type=INT32`).
+//
+// The order of the options is kept.
+func Options(values []string, sep byte) []OptionPair {
+	if len(values) == 0 {
+		return nil
+	}
+
+	options := make([]OptionPair, 0, len(values))
+
+	for _, value := range values {
+		i := strings.IndexByte(value, sep)
+		if i < 0 {
+			options = append(options, OptionPair{Name: strings.TrimSpace(value), Flag: true})
+
+			continue
+		}
+
+		options = append(options, OptionPair{
+			Name:  strings.TrimSpace(value[:i]),
+			Value: strings.TrimSpace(value[i+1:]),
+		})
+	}
+
+	return options
+}
@@ -0,0 +1,84 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptions(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		values   []string
+		sep      byte
+		expected []OptionPair
+	}{
+		{
+			desc: "no values",
+			sep:  '=',
+		},
+		{
+			desc:   "equal separator",
+			values: []string{"min=1", "max=10"},
+			sep:    '=',
+			expected: []OptionPair{
+				{Name: "min", Value: "1"},
+				{Name: "max", Value: "10"},
+			},
+		},
+		{
+			desc:   "colon separator",
+			values: []string{"column:id", "type:int"},
+			sep:    ':',
+			expected: []OptionPair{
+				{Name: "column", Value: "id"},
+				{Name: "type", Value: "int"},
+			},
+		},
+		{
+			desc:   "flags",
+			values: []string{"name", "omitempty", "default=a=b"},
+			sep:    '=',
+			expected: []OptionPair{
+				{Name: "name", Flag: true},
+				{Name: "omitempty", Flag: true},
+				{Name: "default", Value: "a=b"},
+			},
+		},
+		{
+			desc:   "empty value",
+			values: []string{"default=", ""},
+			sep:    '=',
+			expected: []OptionPair{
+				{Name: "default"},
+				{Flag: true},
+			},
+		},
+		{
+			desc:   "non-ASCII byte separator",
+			values: []string{"min\xa71", "omitempty"},
+			sep:    0xa7,
+			expected: []OptionPair{
+				{Name: "min", Value: "1"},
+				{Name: "omitempty", Flag: true},
+			},
+		},
+		{
+			desc:   "spaces",
+			values: []string{"name=x", " type = INT32 "},
+			sep:    '=',
+			expected: []OptionPair{
+				{Name: "name", Value: "x"},
+				{Name: "type", Value: "INT32"},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, Options(test.values, test.sep))
+		})
+	}
+}
@@ -1,12 +1,8 @@
-// Copyright 2009 The Go Authors. All rights reserved.
-// Use of this source code is governed by a BSD-style
-// license that can be found in the LICENSE file.
-
 package parser
 
 import (
-	"fmt"
-	"strconv"
+	"errors"
+	"slices"
 )
 
 // Filler is the interface implemented by types that can fill elements from a struct tag.
@@ -19,97 +15,123 @@ type Filler[T any] interface {
 }
 
 // Tag parses a struct tag.
-//
-// Based on https://github.com/golang/go/blob/411c250d64304033181c46413a6e9381e8fe9b82/src/reflect/type.go#L1030-L1108
-//
-//nolint:gocyclo
-func Tag[T any](tag string, filler Filler[T]) (T, error) {
-	base := tag
-
-	for tag != "" {
-		// Skip leading space.
-		i := 0
-		for i < len(tag) && tag[i] == ' ' {
-			i++
-		}
+// The syntax errors are returned as [*SyntaxError].
+// If the filler implements [SpanFiller], the location of each pair is also provided.
+func Tag[T any](tag string, filler Filler[T], options ...TagOption) (T, error) {
+	cfg := newTagConfig(options)
 
-		tag = tag[i:]
-		if tag == "" {
-			break
-		}
+	spanFiller, withSpans := filler.(SpanFiller)
 
-		// Scan to colon. A space, a quote or a control character is a syntax error.
-		// Strictly speaking, control chars include the range [0x7f, 0x9f], not just
-		// [0x00, 0x1f], but in practice, we ignore the multi-byte control characters
-		// as it is simpler to inspect the tag's bytes than the tag's runes.
-		i = 0
-		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
-			i++
+	err := walk(tag, cfg, func(p pair) (bool, error) {
+		if withSpans {
+			return true, spanFiller.FillSpans(p.key, p.value, p.spans())
 		}
 
-		switch {
-		case i == 0:
-			var zero T
+		return true, filler.Fill(p.key, p.value)
+	})
+	if err != nil && !cfg.Recovery {
+		var zero T
 
-			return zero, fmt.Errorf("invalid struct tag syntax `%s`", base)
+		return zero, err
+	}
 
-		case i+1 > len(tag):
-			var zero T
+	return filler.Data(), err
+}
 
-			return zero, fmt.Errorf("invalid struct tag syntax `%s`: missing `:`", base)
+// walk scans the pairs of a struct tag, and calls fn for each valid pair until fn returns false.
+// The errors returned by fn are positioned with [positionError].
+// In recovery mode, all the errors are returned joined.
+//
+//nolint:gocyclo
+func walk(tag string, cfg tagConfig, fn func(p pair) (bool, error)) error {
+	var errs []error
 
-		case i+1 == len(tag):
-			var zero T
+	// invalidKeys are the keys with an invalid value (reflect compatibility mode).
+	var invalidKeys []string
 
-			return zero, fmt.Errorf("invalid struct tag value `%s`", base)
+	s := newScanner(tag, cfg)
 
-		case tag[i] != ':':
-			var zero T
+scan:
+	for {
+		p, ok, err := s.next()
+		if err != nil {
+			switch {
+			case cfg.ReflectCompat && ok:
+				// Like reflect.StructTag.Lookup, the key is considered as absent.
+				invalidKeys = append(invalidKeys, p.key)
 
-			return zero, fmt.Errorf("invalid struct tag syntax `%s`: missing `:`", base)
+				continue
 
-		case tag[i+1] != '"':
-			var zero T
+			case cfg.ReflectCompat:
+				// Like reflect.StructTag.Lookup, the rest of the tag is ignored.
+				break scan
 
-			return zero, fmt.Errorf("invalid struct tag value `%s`: missing opening quote", base)
-		}
+			case cfg.Recovery:
+				errs = append(errs, err)
 
-		name := tag[:i]
-		tag = tag[i+1:]
+				// A value error is returned after the pair: the scanning continues after the pair.
+				if !ok {
+					s.recover()
+				}
 
-		// Scan quoted string to find value.
-		i = 1
-		for i < len(tag) && tag[i] != '"' {
-			if tag[i] == '\\' {
-				i++
-			}
+				continue
 
-			i++
+			default:
+				return err
+			}
 		}
 
-		if i >= len(tag) {
-			var zero T
-
-			return zero, fmt.Errorf("invalid struct tag value `%s`: missing closing quote", base)
+		if !ok {
+			break
 		}
 
-		qvalue := tag[:i+1]
-		tag = tag[i+1:]
+		if slices.Contains(invalidKeys, p.key) || cfg.skipKey(p.key) {
+			continue
+		}
 
-		value, err := strconv.Unquote(qvalue)
+		more, err := fn(p)
 		if err != nil {
-			var zero T
+			if !cfg.Recovery {
+				return positionError(err, tag, p)
+			}
 
-			return zero, fmt.Errorf("invalid struct tag value `%s`: %w", base, err)
+			errs = append(errs, positionError(err, tag, p))
 		}
 
-		err = filler.Fill(name, value)
-		if err != nil {
-			var zero T
-
-			return zero, err
+		if !more {
+			break
 		}
 	}
 
-	return filler.Data(), nil
+	return errors.Join(errs...)
+}
+
+// positionError sets the position of a [*SyntaxError] returned by a [Filler]:
+//   - an error without position (e.g., duplicate key) is positioned on the key,
+//   - an error positioned in the value (e.g., returned by [Value]) is positioned in the struct tag.
+func positionError(err error, tag string, p pair) error {
+	var syntaxErr *SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		return err
+	}
+
+	switch syntaxErr.Tag {
+	case "":
+		syntaxErr.Offset = p.keyStart
+
+	case p.value:
+		// The offset is relative to the value, after the opening quote.
+		syntaxErr.Offset += p.valueStart + 1
+
+	default:
+		return err
+	}
+
+	syntaxErr.Tag = tag
+
+	if syntaxErr.Key == "" {
+		syntaxErr.Key = p.key
+	}
+
+	return err
 }
@@ -2,7 +2,11 @@ package parser
 
 import (
 	"errors"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
+	"unicode"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -127,62 +131,104 @@ func TestParseTag_error(t *testing.T) {
 	testCases := []struct {
 		desc     string
 		tag      string
+		kind     ErrorKind
+		offset   int
+		key      string
 		expected string
 	}{
 		{
 			desc:     "missing colon",
 			tag:      `json`,
+			kind:     KindMissingColon,
+			offset:   4,
+			key:      "json",
 			expected: "invalid struct tag syntax `json`: missing `:`",
 		},
 		{
 			desc:     "no value",
 			tag:      `json:`,
-			expected: "invalid struct tag value `json:`",
+			kind:     KindMissingOpeningQuote,
+			offset:   5,
+			key:      "json",
+			expected: "invalid struct tag value `json:`: missing opening quote",
 		},
 		{
 			desc:     "missing quotes",
 			tag:      `json:q`,
+			kind:     KindMissingOpeningQuote,
+			offset:   5,
+			key:      "json",
 			expected: "invalid struct tag value `json:q`: missing opening quote",
 		},
 		{
 			desc:     "missing opening quote",
 			tag:      `json:q"`,
+			kind:     KindMissingOpeningQuote,
+			offset:   5,
+			key:      "json",
 			expected: "invalid struct tag value `json:q\"`: missing opening quote",
 		},
 		{
 			desc:     "missing closing quote without value",
 			tag:      `json:"`,
+			kind:     KindMissingClosingQuote,
+			offset:   5,
+			key:      "json",
 			expected: "invalid struct tag value `json:\"`: missing closing quote",
 		},
 		{
 			desc:     "missing closing quote",
 			tag:      `json:"a`,
+			kind:     KindMissingClosingQuote,
+			offset:   5,
+			key:      "json",
 			expected: "invalid struct tag value `json:\"a`: missing closing quote",
 		},
 		{
 			desc:     "too many quotes (end)",
 			tag:      `json:"a""`,
-			expected: "invalid struct tag syntax `json:\"a\"\"`",
+			kind:     KindInvalidKeyChar,
+			offset:   8,
+			expected: "invalid struct tag syntax `json:\"a\"\"`: invalid key character",
 		},
 		{
 			desc:     "too many quotes (start)",
 			tag:      `json:""a"`,
-			expected: "invalid struct tag value `json:\"\"a\"`",
+			kind:     KindMissingColon,
+			offset:   8,
+			key:      "a",
+			expected: "invalid struct tag syntax `json:\"\"a\"`: missing `:`",
 		},
 		{
 			desc:     "space after the key",
 			tag:      `json:  `,
+			kind:     KindMissingOpeningQuote,
+			offset:   5,
+			key:      "json",
 			expected: "invalid struct tag value `json:  `: missing opening quote",
 		},
 		{
 			desc:     "space",
 			tag:      `json:"   `,
+			kind:     KindMissingClosingQuote,
+			offset:   5,
+			key:      "json",
 			expected: "invalid struct tag value `json:\"   `: missing closing quote",
 		},
 		{
 			desc:     "tab",
 			tag:      `json:"a"	yaml:"b"`,
-			expected: "invalid struct tag syntax `json:\"a\"\tyaml:\"b\"`",
+			kind:     KindInvalidKeyChar,
+			offset:   8,
+			expected: "invalid struct tag syntax `json:\"a\"\tyaml:\"b\"`: invalid key character",
+		},
+		{
+			desc:     "bad escape",
+			tag:      `json:"a" yaml:"\q"`,
+			kind:     KindBadEscape,
+			offset:   14,
+			key:      "yaml",
+			expected: "invalid struct tag value `json:\"a\" yaml:\"\\q\"`: invalid syntax",
 		},
 		{
 			desc:     "filler error",
@@ -199,6 +245,704 @@ func TestParseTag_error(t *testing.T) {
 			require.Error(t, err)
 
 			assert.EqualError(t, err, test.expected)
+
+			if test.kind == 0 {
+				return
+			}
+
+			var syntaxErr *SyntaxError
+			require.ErrorAs(t, err, &syntaxErr)
+
+			assert.Equal(t, test.kind, syntaxErr.Kind)
+			assert.Equal(t, test.tag, syntaxErr.Tag)
+			assert.Equal(t, test.offset, syntaxErr.Offset)
+			assert.Equal(t, test.key, syntaxErr.Key)
+
+			assert.ErrorIs(t, err, test.kind.sentinel())
+		})
+	}
+}
+
+func TestParseTag_error_filler(t *testing.T) {
+	_, err := Tag(`json:"a" dup:"b"`, &duplicateFiller{})
+	require.Error(t, err)
+
+	assert.EqualError(t, err, `duplicate key "dup"`)
+	require.ErrorIs(t, err, ErrDuplicateKey)
+
+	var syntaxErr *SyntaxError
+	require.ErrorAs(t, err, &syntaxErr)
+
+	assert.Equal(t, `json:"a" dup:"b"`, syntaxErr.Tag)
+	assert.Equal(t, 9, syntaxErr.Offset)
+	assert.Equal(t, "dup", syntaxErr.Key)
+}
+
+type duplicateFiller struct {
+	TestFiller
+}
+
+func (f *duplicateFiller) Fill(key, value string) error {
+	if key == "dup" {
+		return NewDuplicateKeyError(key)
+	}
+
+	return f.TestFiller.Fill(key, value)
+}
+
+func TestParseTag_recovery(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		tag      string
+		expected []TestTag
+		errCount int
+		kinds    []ErrorKind
+	}{
+		{
+			desc: "no error",
+			tag:  `json:"a" yaml:"b"`,
+			expected: []TestTag{
+				{Key: "json", Value: "a"},
+				{Key: "yaml", Value: "b"},
+			},
+		},
+		{
+			desc:     "multiple errors",
+			tag:      `json:"a" yaml:b xml:"c`,
+			expected: []TestTag{{Key: "json", Value: "a"}},
+			errCount: 2,
+			kinds:    []ErrorKind{KindMissingOpeningQuote, KindMissingClosingQuote},
+		},
+		{
+			desc: "valid pairs after errors",
+			tag:  `json yaml:b xml:"c" toml:"d"`,
+			expected: []TestTag{
+				{Key: "xml", Value: "c"},
+				{Key: "toml", Value: "d"},
+			},
+			errCount: 2,
+			kinds:    []ErrorKind{KindMissingColon, KindMissingOpeningQuote},
+		},
+		{
+			desc:     "space inside unterminated value",
+			tag:      `json:"a b`,
+			expected: nil,
+			errCount: 1,
+			kinds:    []ErrorKind{KindMissingClosingQuote},
+		},
+		{
+			desc:     "bad escape",
+			tag:      `json:"\q" yaml:"b"`,
+			expected: []TestTag{{Key: "yaml", Value: "b"}},
+			errCount: 1,
+			kinds:    []ErrorKind{KindBadEscape},
+		},
+		{
+			desc:     "filler error",
+			tag:      `oops:"a" yaml:"b"`,
+			expected: []TestTag{{Key: "yaml", Value: "b"}},
+			errCount: 1,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			tags, err := Tag(test.tag, &TestFiller{}, WithRecovery())
+
+			assert.Equal(t, test.expected, tags)
+
+			if test.errCount == 0 {
+				require.NoError(t, err)
+
+				return
+			}
+
+			joinErr, ok := err.(interface{ Unwrap() []error })
+			require.True(t, ok)
+
+			assert.Len(t, joinErr.Unwrap(), test.errCount)
+
+			for _, kind := range test.kinds {
+				require.ErrorIs(t, err, kind.sentinel())
+			}
+		})
+	}
+}
+
+type TestSpanTag struct {
+	Key   string
+	Value string
+	Spans Spans
+}
+
+type TestSpanFiller struct {
+	data []TestSpanTag
+}
+
+func (f *TestSpanFiller) Data() []TestSpanTag {
+	return f.data
+}
+
+func (f *TestSpanFiller) Fill(_, _ string) error {
+	return errors.New("should not be called")
+}
+
+func (f *TestSpanFiller) FillSpans(key, value string, spans Spans) error {
+	f.data = append(f.data, TestSpanTag{
+		Key:   key,
+		Value: value,
+		Spans: spans,
+	})
+
+	return nil
+}
+
+func TestParseTag_spans(t *testing.T) {
+	tag := ` json:"a,b"  yaml:"\"c\"" xml:""`
+
+	tags, err := Tag(tag, &TestSpanFiller{})
+	require.NoError(t, err)
+
+	expected := []TestSpanTag{
+		{
+			Key:   "json",
+			Value: "a,b",
+			Spans: Spans{
+				Key:         Span{Start: 1, End: 5},
+				QuotedValue: Span{Start: 6, End: 11},
+				Value:       Span{Start: 7, End: 10},
+			},
+		},
+		{
+			Key:   "yaml",
+			Value: `"c"`,
+			Spans: Spans{
+				Key:         Span{Start: 13, End: 17},
+				QuotedValue: Span{Start: 18, End: 25},
+				Value:       Span{Start: 19, End: 24},
+			},
+		},
+		{
+			Key:   "xml",
+			Value: "",
+			Spans: Spans{
+				Key:         Span{Start: 26, End: 29},
+				QuotedValue: Span{Start: 30, End: 32},
+				Value:       Span{Start: 31, End: 31},
+			},
+		},
+	}
+
+	assert.Equal(t, expected, tags)
+
+	for _, e := range tags {
+		assert.Equal(t, e.Key, tag[e.Spans.Key.Start:e.Spans.Key.End])
+		assert.Equal(t, tag[e.Spans.QuotedValue.Start+1:e.Spans.QuotedValue.End-1], tag[e.Spans.Value.Start:e.Spans.Value.End])
+	}
+}
+
+// firstWinsFiller keeps the first value of each key, like [reflect.StructTag.Lookup].
+type firstWinsFiller struct {
+	data map[string]string
+}
+
+func (f *firstWinsFiller) Data() map[string]string {
+	return f.data
+}
+
+func (f *firstWinsFiller) Fill(key, value string) error {
+	if f.data == nil {
+		f.data = map[string]string{}
+	}
+
+	if _, ok := f.data[key]; !ok {
+		f.data[key] = value
+	}
+
+	return nil
+}
+
+func TestParseTag_reflectCompat(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		tag      string
+		expected map[string]string
+	}{
+		{
+			desc:     "valid",
+			tag:      `json:"a" yaml:"b"`,
+			expected: map[string]string{"json": "a", "yaml": "b"},
+		},
+		{
+			desc:     "missing colon",
+			tag:      `json:"a" yaml xml:"c"`,
+			expected: map[string]string{"json": "a"},
+		},
+		{
+			desc:     "missing opening quote",
+			tag:      `json:"a" yaml:b xml:"c"`,
+			expected: map[string]string{"json": "a"},
+		},
+		{
+			desc:     "missing closing quote",
+			tag:      `json:"a" yaml:"b`,
+			expected: map[string]string{"json": "a"},
+		},
+		{
+			desc:     "bad escape",
+			tag:      `json:"\q" yaml:"b" json:"c"`,
+			expected: map[string]string{"yaml": "b"},
+		},
+		{
+			desc:     "only errors",
+			tag:      `json`,
+			expected: nil,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			tags, err := Tag(test.tag, &firstWinsFiller{}, WithReflectCompat())
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, tags)
+
+			assertReflectCompat(t, test.tag, tags)
+		})
+	}
+}
+
+func FuzzTag_reflectCompat(f *testing.F) {
+	seeds := []string{
+		``,
+		` `,
+		`json:"a"`,
+		`json:"a" yaml:"b"`,
+		`json:"a"yaml:"b"`,
+		`json:"a" json:"b"`,
+		`json:"a" yaml xml:"c"`,
+		`json:"a" yaml:b xml:"c"`,
+		`json:"a" yaml:"b`,
+		`json:"\q" yaml:"b" json:"c"`,
+		`json:"a\"b" yaml:"\x41"`,
+		"json:\"a\"\tyaml:\"b\"",
+		"json:\"a\nb\" yaml:\"c\"",
+		"j\x7fson:\"a\"",
+		"é:\"a\" \xff:\"b\"",
+	}
+
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, tag string) {
+		tags, err := Tag(tag, &firstWinsFiller{}, WithReflectCompat())
+		require.NoError(t, err)
+
+		assertReflectCompat(t, tag, tags)
+	})
+}
+
+func assertReflectCompat(t *testing.T, tag string, tags map[string]string) {
+	t.Helper()
+
+	keys := strings.FieldsFunc(tag, func(r rune) bool {
+		return r <= ' ' || r == ':' || r == '"' || r == 0x7f
+	})
+
+	for key := range tags {
+		keys = append(keys, key)
+	}
+
+	for _, key := range keys {
+		expected, expectedOK := reflect.StructTag(tag).Lookup(key)
+
+		value, ok := tags[key]
+
+		assert.Equal(t, expectedOK, ok, "key %q", key)
+		assert.Equal(t, expected, value, "key %q", key)
+	}
+}
+
+func TestParseTag_strict(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		tag    string
+		kind   ErrorKind
+		offset int
+	}{
+		{
+			desc:   "leading space",
+			tag:    ` json:"a"`,
+			kind:   KindLeadingSpace,
+			offset: 0,
+		},
+		{
+			desc:   "trailing space",
+			tag:    `json:"a" `,
+			kind:   KindTrailingSpace,
+			offset: 8,
+		},
+		{
+			desc:   "only spaces",
+			tag:    `  `,
+			kind:   KindLeadingSpace,
+			offset: 0,
+		},
+		{
+			desc:   "multiple spaces",
+			tag:    `json:"a"  yaml:"b"`,
+			kind:   KindMultipleSpaces,
+			offset: 8,
+		},
+		{
+			desc:   "missing separator",
+			tag:    `json:"a"yaml:"b"`,
+			kind:   KindMissingSeparator,
+			offset: 8,
+		},
+		{
+			desc:   "empty key",
+			tag:    `json:"a" :"b"`,
+			kind:   KindEmptyKey,
+			offset: 9,
+		},
+		{
+			desc:   "non-ASCII key",
+			tag:    `json:"a" yéml:"b"`,
+			kind:   KindNonASCIIKey,
+			offset: 10,
+		},
+		{
+			desc:   "invalid UTF-8 value",
+			tag:    "json:\"a\xff\"",
+			kind:   KindInvalidUTF8Value,
+			offset: 5,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Tag(test.tag, &TestFiller{}, WithStrict())
+			require.ErrorIs(t, err, test.kind.sentinel())
+
+			var syntaxErr *SyntaxError
+			require.ErrorAs(t, err, &syntaxErr)
+
+			assert.Equal(t, test.kind, syntaxErr.Kind)
+			assert.Equal(t, test.offset, syntaxErr.Offset)
+		})
+	}
+}
+
+func TestParseTag_strict_valid(t *testing.T) {
+	tags, err := Tag(`json:"a,omitempty" yaml:"b"`, &TestFiller{}, WithStrict())
+	require.NoError(t, err)
+
+	expected := []TestTag{
+		{Key: "json", Value: "a,omitempty"},
+		{Key: "yaml", Value: "b"},
+	}
+
+	assert.Equal(t, expected, tags)
+}
+
+func TestParseTag_strict_recovery(t *testing.T) {
+	tags, err := Tag(` json:"a"  yaml:"b"xml:"c" `, &TestFiller{}, WithStrict(), WithRecovery())
+
+	expected := []TestTag{
+		{Key: "json", Value: "a"},
+		{Key: "yaml", Value: "b"},
+		{Key: "xml", Value: "c"},
+	}
+
+	assert.Equal(t, expected, tags)
+
+	require.ErrorIs(t, err, ErrLeadingSpace)
+	require.ErrorIs(t, err, ErrMultipleSpaces)
+	require.ErrorIs(t, err, ErrMissingSeparator)
+	require.ErrorIs(t, err, ErrTrailingSpace)
+}
+
+func TestParseTag_options(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		tag      string
+		options  []TagOption
+		expected []TestTag
+	}{
+		{
+			desc:     "max pairs",
+			tag:      `json:"a" yaml:"b"`,
+			options:  []TagOption{WithMaxPairs(2)},
+			expected: []TestTag{{Key: "json", Value: "a"}, {Key: "yaml", Value: "b"}},
+		},
+		{
+			desc:     "max length",
+			tag:      `json:"a"`,
+			options:  []TagOption{WithMaxLength(8)},
+			expected: []TestTag{{Key: "json", Value: "a"}},
+		},
+		{
+			desc:     "key chars",
+			tag:      `json:"a" yaml:"b"`,
+			options:  []TagOption{WithKeyChars(unicode.IsLetter)},
+			expected: []TestTag{{Key: "json", Value: "a"}, {Key: "yaml", Value: "b"}},
+		},
+		{
+			desc:     "default whitespace",
+			tag:      `  json:"a"   yaml:"b"`,
+			options:  []TagOption{WithWhitespace(WhitespaceDefault)},
+			expected: []TestTag{{Key: "json", Value: "a"}, {Key: "yaml", Value: "b"}},
+		},
+		{
+			desc:     "lenient whitespace",
+			tag:      "\tjson:\"a\"\n\t yaml:\"b\"\r\n",
+			options:  []TagOption{WithWhitespace(WhitespaceLenient)},
+			expected: []TestTag{{Key: "json", Value: "a"}, {Key: "yaml", Value: "b"}},
+		},
+		{
+			desc:     "multi-byte control characters are allowed by default",
+			tag:      "js\u0085on:\"a\"",
+			expected: []TestTag{{Key: "js\u0085on", Value: "a"}},
+		},
+		{
+			desc:     "keys",
+			tag:      `json:"a" yaml:"b" oops:"c" xml:"d"`,
+			options:  []TagOption{WithKeys("json", "xml")},
+			expected: []TestTag{{Key: "json", Value: "a"}, {Key: "xml", Value: "d"}},
+		},
+		{
+			desc:     "without keys",
+			tag:      `json:"a" yaml:"b" oops:"c" xml:"d"`,
+			options:  []TagOption{WithoutKeys("oops", "yaml")},
+			expected: []TestTag{{Key: "json", Value: "a"}, {Key: "xml", Value: "d"}},
+		},
+		{
+			desc:     "keys and without keys",
+			tag:      `json:"a" yaml:"b" xml:"d"`,
+			options:  []TagOption{WithKeys("json", "yaml"), WithoutKeys("yaml")},
+			expected: []TestTag{{Key: "json", Value: "a"}},
+		},
+		{
+			desc:     "empty keys",
+			tag:      `json:"a" yaml:"b"`,
+			options:  []TagOption{WithKeys()},
+			expected: []TestTag{{Key: "json", Value: "a"}, {Key: "yaml", Value: "b"}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			tags, err := Tag(test.tag, &TestFiller{}, test.options...)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, tags)
+		})
+	}
+}
+
+func TestParseTag_options_error(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		tag     string
+		options []TagOption
+		kind    ErrorKind
+		offset  int
+	}{
+		{
+			desc:    "max pairs",
+			tag:     `json:"a" yaml:"b" xml:"c"`,
+			options: []TagOption{WithMaxPairs(2)},
+			kind:    KindTooManyPairs,
+			offset:  18,
+		},
+		{
+			desc:    "max length",
+			tag:     `json:"a" yaml:"b"`,
+			options: []TagOption{WithMaxLength(8)},
+			kind:    KindTagTooLong,
+			offset:  8,
+		},
+		{
+			desc: "key chars",
+			tag:  `json:"a" yaml.v3:"b"`,
+			options: []TagOption{WithKeyChars(func(r rune) bool {
+				return r != '.' && r != '/'
+			})},
+			kind:   KindDisallowedKeyChar,
+			offset: 13,
+		},
+		{
+			desc:    "multi-byte control characters",
+			tag:     "js\u0085on:\"a\"",
+			options: []TagOption{WithMultiByteControls()},
+			kind:    KindMissingColon,
+			offset:  2,
+		},
+		{
+			desc:    "multi-byte control characters at the start of the key",
+			tag:     "\u0085json:\"a\"",
+			options: []TagOption{WithMultiByteControls()},
+			kind:    KindInvalidKeyChar,
+			offset:  0,
+		},
+		{
+			desc:    "default whitespace",
+			tag:     "json:\"a\"\tyaml:\"b\"",
+			options: []TagOption{WithWhitespace(WhitespaceDefault)},
+			kind:    KindInvalidKeyChar,
+			offset:  8,
+		},
+		{
+			desc:    "strict whitespace",
+			tag:     `json:"a"  yaml:"b"`,
+			options: []TagOption{WithWhitespace(WhitespaceStrict)},
+			kind:    KindMultipleSpaces,
+			offset:  8,
+		},
+		{
+			desc:    "skipped keys are syntax-checked",
+			tag:     `json:"a" yaml:"b\x"`,
+			options: []TagOption{WithKeys("json")},
+			kind:    KindBadEscape,
+			offset:  14,
+		},
+		{
+			desc:    "excluded keys are syntax-checked",
+			tag:     `json:"a" yaml:b`,
+			options: []TagOption{WithoutKeys("yaml")},
+			kind:    KindMissingOpeningQuote,
+			offset:  14,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Tag(test.tag, &TestFiller{}, test.options...)
+			require.ErrorIs(t, err, test.kind.sentinel())
+
+			var syntaxErr *SyntaxError
+			require.ErrorAs(t, err, &syntaxErr)
+
+			assert.Equal(t, test.kind, syntaxErr.Kind)
+			assert.Equal(t, test.offset, syntaxErr.Offset)
+		})
+	}
+}
+
+func TestParseTag_options_recovery(t *testing.T) {
+	tags, err := Tag(`json:"a" yaml:"b" xml:"c"`, &TestFiller{}, WithMaxPairs(1), WithRecovery())
+	require.ErrorIs(t, err, ErrTooManyPairs)
+
+	assert.Equal(t, []TestTag{{Key: "json", Value: "a"}}, tags)
+
+	// The scanning continues after a pair with an invalid value, not inside the value.
+	tags, err = Tag(`a:"x\q y:z" b:"c"`, &TestFiller{}, WithRecovery())
+	require.ErrorIs(t, err, ErrBadEscape)
+	require.NotErrorIs(t, err, ErrMissingOpeningQuote)
+
+	assert.Equal(t, []TestTag{{Key: "b", Value: "c"}}, tags)
+
+	tags, err = Tag("a:\"\xff y:z\" b:\"c\"", &TestFiller{}, WithStrict(), WithRecovery())
+	require.ErrorIs(t, err, ErrInvalidUTF8Value)
+	require.NotErrorIs(t, err, ErrMissingOpeningQuote)
+
+	assert.Equal(t, []TestTag{{Key: "b", Value: "c"}}, tags)
+}
+
+func TestParseTag_unquote(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		qvalue string
+	}{
+		{desc: "empty", qvalue: `""`},
+		{desc: "ASCII", qvalue: `"a,b"`},
+		{desc: "tab", qvalue: "\"a\tb\""},
+		{desc: "control character", qvalue: "\"a\x01b\""},
+		{desc: "UTF-8", qvalue: `"é名"`},
+		{desc: "escape sequence", qvalue: `"a\tb"`},
+		{desc: "escaped quote", qvalue: `"a\"b"`},
+		{desc: "newline", qvalue: "\"a\nb\""},
+		{desc: "invalid UTF-8", qvalue: "\"a\xffb\""},
+		{desc: "truncated UTF-8", qvalue: "\"a\xc3\""},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			tags, err := Tag("a:"+test.qvalue, &TestFiller{})
+
+			// Same result as strconv.Unquote.
+			expected, expectedErr := strconv.Unquote(test.qvalue)
+			if expectedErr != nil {
+				require.ErrorIs(t, err, ErrBadEscape)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			assert.Equal(t, []TestTag{{Key: "a", Value: expected}}, tags)
+		})
+	}
+}
+
+// countFiller counts the pairs.
+type countFiller struct {
+	count int
+}
+
+func (f *countFiller) Data() int {
+	return f.count
+}
+
+func (f *countFiller) Fill(_, _ string) error {
+	f.count++
+
+	return nil
+}
+
+func BenchmarkTag(b *testing.B) {
+	benchmarks := []struct {
+		desc string
+		tag  string
+	}{
+		{
+			desc: "ASCII",
+			tag:  `json:"name,omitempty" yaml:"name" xml:"name,attr" toml:"name" validate:"required,min=1,max=255"`,
+		},
+		{
+			desc: "UTF-8",
+			tag:  `json:"nom,omitempty" description:"Le prénom de l'utilisateur" label:"名前"`,
+		},
+		{
+			desc: "escaped",
+			tag:  `json:"name,omitempty" pattern:"^\\d+$" description:"a \"quoted\" name"`,
+		},
+	}
+
+	for _, bench := range benchmarks {
+		b.Run(bench.desc, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for b.Loop() {
+				_, err := Tag(bench.tag, &countFiller{})
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
 		})
 	}
 }
@@ -0,0 +1,314 @@
+package parser
+
+// WithBrackets groups the values inside brackets: the separators inside the brackets are ignored.
+// Each pair is an opening and a closing bracket (e.g., "[]", "()", "{}"), all of them by default.
+// The unbalanced brackets are reported ([KindUnbalancedBracket]).
+//
+// [ValueTree] returns the groups as a tree.
+func WithBrackets(pairs ...string) ValueOption {
+	if len(pairs) == 0 {
+		pairs = []string{"[]", "()", "{}"}
+	}
+
+	return func(cfg *valueConfig) {
+		for _, pair := range pairs {
+			if len(pair) == 2 {
+				cfg.Brackets += pair
+			}
+		}
+	}
+}
+
+// ValueNode is a value, or a group of values, returned by [ValueTree].
+type ValueNode struct {
+	// Value is the value.
+	// For a group, this is the raw text of the group (prefix and brackets included).
+	Value string
+
+	// Span is the location of the value inside the raw value.
+	Span Span
+
+	// Prefix is the text before the opening bracket of a group (e.g., `keys` for `keys(a,b)`).
+	Prefix string
+
+	// Open is the opening bracket of a group (0 if the node is not a group).
+	Open byte
+
+	// Close is the closing bracket of a group (0 if the node is not a group).
+	Close byte
+
+	// Children are the values inside the brackets of a group.
+	Children []ValueNode
+}
+
+// IsGroup returns true if the node is a group of values.
+func (n ValueNode) IsGroup() bool {
+	return n.Open != 0
+}
+
+// defaultMaxDepth is the maximum nesting of the brackets used by [ValueTree] without [WithMaxDepth].
+const defaultMaxDepth = 1000
+
+// WithMaxDepth limits the nesting of the brackets ([KindTooDeep]).
+// [ValueTree] limits the nesting to 1000 by default.
+func WithMaxDepth(n int) ValueOption {
+	return func(cfg *valueConfig) {
+		cfg.MaxDepth = n
+	}
+}
+
+// ValueTree parses a tag value like [Value], and returns the groups of values as a tree
+// (see [WithBrackets], all the brackets are used by default).
+// A value is a group when it ends with the bracket closing its first bracket (e.g., `[a,b]` or `keys(a,b)`),
+// the values inside the brackets are the children of the group.
+//
+// The nesting of the brackets is limited (see [WithMaxDepth]).
+func ValueTree(raw string, escapeComma bool, options ...ValueOption) ([]ValueNode, error) {
+	cfg := newValueConfig(escapeComma)
+
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	if cfg.Brackets == "" {
+		WithBrackets()(&cfg)
+	}
+
+	if cfg.MaxDepth <= 0 {
+		cfg.MaxDepth = defaultMaxDepth
+	}
+
+	// match are the offsets of the closing brackets, by offset of the opening brackets.
+	match := make([]int, len(raw))
+
+	// The brackets are checked once, the nested groups are not checked again.
+	err := cfg.split(raw, 0, len(raw), func(pos, n int) error {
+		return cfg.matchBrackets(raw, pos, pos+n, match)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg.tree(raw, match)
+}
+
+// treeLevel is the state of the values of a group (or of the raw value) while building a tree.
+type treeLevel struct {
+	// pos is the offset of the next value.
+	pos int
+
+	// end is the end of the values (the closing bracket of the group).
+	end int
+
+	// done is true when all the values have been read.
+	done bool
+
+	// count is the number of values.
+	count int
+
+	nodes []ValueNode
+}
+
+// tree returns the nodes of raw.
+// The groups are read with an explicit stack: the nesting of the brackets doesn't grow the call stack.
+func (cfg *valueConfig) tree(raw string, match []int) ([]ValueNode, error) {
+	stack := []treeLevel{{end: len(raw)}}
+
+	for {
+		level := &stack[len(stack)-1]
+
+		if level.done {
+			if len(stack) == 1 {
+				return level.nodes, nil
+			}
+
+			children := level.nodes
+
+			stack = stack[:len(stack)-1]
+
+			parent := &stack[len(stack)-1]
+			parent.nodes[len(parent.nodes)-1].Children = children
+
+			continue
+		}
+
+		pos := level.pos
+
+		n, open, err := cfg.treeSegment(raw, pos, level.end, match)
+		if err != nil {
+			return nil, err
+		}
+
+		if cfg.MaxValues > 0 && level.count >= cfg.MaxValues {
+			return nil, &SyntaxError{Kind: KindTooManyValues, Tag: raw, Offset: pos}
+		}
+
+		if cfg.MaxValueLength > 0 && n > cfg.MaxValueLength {
+			return nil, &SyntaxError{Kind: KindValueTooLong, Tag: raw, Offset: pos + cfg.MaxValueLength}
+		}
+
+		level.count++
+
+		if pos+n >= level.end {
+			level.done = true
+		} else {
+			// Skip the separator.
+			level.pos = pos + n + 1
+		}
+
+		if open < 0 {
+			value, err := cfg.value(raw, pos, n)
+			if err != nil {
+				return nil, err
+			}
+
+			level.nodes = append(level.nodes, ValueNode{Value: value, Span: Span{Start: pos, End: pos + n}})
+
+			continue
+		}
+
+		segment := raw[pos : pos+n]
+
+		level.nodes = append(level.nodes, ValueNode{
+			Value:  segment,
+			Span:   Span{Start: pos, End: pos + n},
+			Prefix: segment[:open],
+			Open:   segment[open],
+			Close:  segment[n-1],
+		})
+
+		// The level pointer is not valid after the append.
+		stack = append(stack, treeLevel{pos: pos + open + 1, end: pos + n - 1})
+	}
+}
+
+// treeSegment returns the length of the value at raw[pos:end],
+// and the index of its opening bracket if the value is a group (-1 otherwise).
+// The groups are skipped with the offsets of the closing brackets (match):
+// each byte is read once for the whole tree.
+func (cfg *valueConfig) treeSegment(raw string, pos, end int, match []int) (int, int, error) {
+	if cfg.isQuoted(raw[pos:end]) {
+		return cfg.segmentLength(raw[pos:end]), -1, nil
+	}
+
+	// first is the offset of the first opening bracket.
+	first := -1
+
+	i := pos
+
+	for ; i < end; i++ {
+		c := raw[i]
+
+		if cfg.Escape && c == cfg.EscapeChar {
+			// Skip the escaped character.
+			i++
+
+			continue
+		}
+
+		if c == cfg.Separator {
+			break
+		}
+
+		switch {
+		case cfg.opening(c) >= 0:
+			// The brackets are checked by matchBrackets: a missing match comes from a quoted value inside a group.
+			if match[i] <= i || match[i] >= end {
+				return 0, 0, &SyntaxError{Kind: KindUnbalancedBracket, Tag: raw, Offset: i}
+			}
+
+			if first < 0 {
+				first = i
+			}
+
+			// Skip the group.
+			i = match[i]
+
+		case cfg.closing(c) >= 0:
+			return 0, 0, &SyntaxError{Kind: KindUnbalancedBracket, Tag: raw, Offset: i}
+		}
+	}
+
+	n := min(i, end) - pos
+
+	// A value is a group when it ends with the bracket closing its first bracket.
+	if first < 0 || match[first] != pos+n-1 {
+		return n, -1, nil
+	}
+
+	return n, first - pos, nil
+}
+
+// checkBrackets checks that the brackets of raw[start:end] are balanced.
+func (cfg *valueConfig) checkBrackets(raw string, start, end int) error {
+	return cfg.matchBrackets(raw, start, end, nil)
+}
+
+// matchBrackets checks that the brackets of raw[start:end] are balanced, and not nested deeper than the limit.
+// If match is not nil, the offset of each closing bracket is set at the offset of its opening bracket.
+func (cfg *valueConfig) matchBrackets(raw string, start, end int, match []int) error {
+	if cfg.Brackets == "" || cfg.isQuoted(raw[start:end]) {
+		return nil
+	}
+
+	// opened are the offsets of the opened brackets.
+	var opened []int
+
+	for i := start; i < end; i++ {
+		c := raw[i]
+
+		switch {
+		case cfg.Escape && c == cfg.EscapeChar:
+			// Skip the escaped character.
+			i++
+
+		case cfg.opening(c) >= 0:
+			opened = append(opened, i)
+
+			if cfg.MaxDepth > 0 && len(opened) > cfg.MaxDepth {
+				return &SyntaxError{Kind: KindTooDeep, Tag: raw, Offset: i}
+			}
+
+		case cfg.closing(c) >= 0:
+			if len(opened) == 0 || cfg.opening(raw[opened[len(opened)-1]]) != cfg.closing(c) {
+				return &SyntaxError{Kind: KindUnbalancedBracket, Tag: raw, Offset: i}
+			}
+
+			if match != nil {
+				match[opened[len(opened)-1]] = i
+			}
+
+			opened = opened[:len(opened)-1]
+		}
+	}
+
+	if len(opened) > 0 {
+		return &SyntaxError{Kind: KindUnbalancedBracket, Tag: raw, Offset: opened[len(opened)-1]}
+	}
+
+	return nil
+}
+
+// opening returns the index of the bracket pair if c is an opening bracket, -1 otherwise.
+func (cfg *valueConfig) opening(c byte) int {
+	return cfg.bracketIndex(c, 0)
+}
+
+// closing returns the index of the bracket pair if c is a closing bracket, -1 otherwise.
+func (cfg *valueConfig) closing(c byte) int {
+	return cfg.bracketIndex(c, 1)
+}
+
+func (cfg *valueConfig) bracketIndex(c byte, side int) int {
+	if cfg.Brackets == "" {
+		return -1
+	}
+
+	for i := side; i < len(cfg.Brackets); i += 2 {
+		if cfg.Brackets[i] == c {
+			return i / 2
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,299 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseValue_brackets(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		raw      string
+		options  []ValueOption
+		expected []string
+	}{
+		{
+			desc:     "group",
+			raw:      "[a,b],c",
+			expected: []string{"[a,b]", "c"},
+		},
+		{
+			desc:     "prefix",
+			raw:      "keys(a,b),endkeys",
+			expected: []string{"keys(a,b)", "endkeys"},
+		},
+		{
+			desc:     "nested groups",
+			raw:      "{a,[b,(c,d)]},e",
+			expected: []string{"{a,[b,(c,d)]}", "e"},
+		},
+		{
+			desc:     "selected brackets",
+			raw:      "[a,b],(c,d)",
+			options:  []ValueOption{WithBrackets("()")},
+			expected: []string{"[a", "b]", "(c,d)"},
+		},
+		{
+			desc:     "space separator",
+			raw:      "oneof=(a b c) required",
+			options:  []ValueOption{WithSeparator(' '), WithBrackets()},
+			expected: []string{"oneof=(a b c)", "required"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			options := test.options
+			if len(options) == 0 {
+				options = []ValueOption{WithBrackets()}
+			}
+
+			values, err := Value(test.raw, false, options...)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, values)
+		})
+	}
+}
+
+func TestValueTree(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		raw         string
+		escapeComma bool
+		options     []ValueOption
+		expected    []ValueNode
+	}{
+		{
+			desc:     "empty",
+			expected: []ValueNode{{}},
+		},
+		{
+			desc: "flat values",
+			raw:  "a,b",
+			expected: []ValueNode{
+				{Value: "a", Span: Span{Start: 0, End: 1}},
+				{Value: "b", Span: Span{Start: 2, End: 3}},
+			},
+		},
+		{
+			desc: "group",
+			raw:  "[a,b],c",
+			expected: []ValueNode{
+				{
+					Value: "[a,b]",
+					Span:  Span{Start: 0, End: 5},
+					Open:  '[',
+					Close: ']',
+					Children: []ValueNode{
+						{Value: "a", Span: Span{Start: 1, End: 2}},
+						{Value: "b", Span: Span{Start: 3, End: 4}},
+					},
+				},
+				{Value: "c", Span: Span{Start: 6, End: 7}},
+			},
+		},
+		{
+			desc: "prefix and nested groups",
+			raw:  "dive(keys[a,b()])",
+			expected: []ValueNode{
+				{
+					Value:  "dive(keys[a,b()])",
+					Span:   Span{Start: 0, End: 17},
+					Prefix: "dive",
+					Open:   '(',
+					Close:  ')',
+					Children: []ValueNode{
+						{
+							Value:  "keys[a,b()]",
+							Span:   Span{Start: 5, End: 16},
+							Prefix: "keys",
+							Open:   '[',
+							Close:  ']',
+							Children: []ValueNode{
+								{Value: "a", Span: Span{Start: 10, End: 11}},
+								{
+									Value:    "b()",
+									Span:     Span{Start: 12, End: 15},
+									Prefix:   "b",
+									Open:     '(',
+									Close:    ')',
+									Children: []ValueNode{{Span: Span{Start: 14, End: 14}}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			desc: "text after the group",
+			raw:  "[a,b]c",
+			expected: []ValueNode{
+				{Value: "[a,b]c", Span: Span{Start: 0, End: 6}},
+			},
+		},
+		{
+			desc:        "escaped bracket",
+			raw:         `\[a,b\]`,
+			escapeComma: true,
+			options:     []ValueOption{WithUnescape()},
+			expected: []ValueNode{
+				{Value: `\[a`, Span: Span{Start: 0, End: 3}},
+				{Value: `b\]`, Span: Span{Start: 4, End: 7}},
+			},
+		},
+		{
+			desc:    "selected brackets",
+			raw:     "{a,b}",
+			options: []ValueOption{WithBrackets("{}")},
+			expected: []ValueNode{
+				{
+					Value: "{a,b}",
+					Span:  Span{Start: 0, End: 5},
+					Open:  '{',
+					Close: '}',
+					Children: []ValueNode{
+						{Value: "a", Span: Span{Start: 1, End: 2}},
+						{Value: "b", Span: Span{Start: 3, End: 4}},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			nodes, err := ValueTree(test.raw, test.escapeComma, test.options...)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, nodes)
+
+			for _, node := range nodes {
+				assert.Equal(t, node.Open != 0, node.IsGroup())
+			}
+		})
+	}
+}
+
+func TestValueTree_error(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		raw     string
+		options []ValueOption
+		kind    ErrorKind
+		offset  int
+	}{
+		{
+			desc:   "not closed",
+			raw:    "a,[b,c",
+			kind:   KindUnbalancedBracket,
+			offset: 2,
+		},
+		{
+			desc:   "not opened",
+			raw:    "a,b],c",
+			kind:   KindUnbalancedBracket,
+			offset: 3,
+		},
+		{
+			desc:   "mismatched brackets",
+			raw:    "[a,(b]),c",
+			kind:   KindUnbalancedBracket,
+			offset: 5,
+		},
+		{
+			desc:   "nested not closed",
+			raw:    "[a,(b]",
+			kind:   KindUnbalancedBracket,
+			offset: 5,
+		},
+		{
+			desc:    "too many values in a group",
+			raw:     "[a,b,c]",
+			options: []ValueOption{WithMaxValues(2)},
+			kind:    KindTooManyValues,
+			offset:  5,
+		},
+		{
+			desc:    "too deep",
+			raw:     "a,[b,(c,{d})]",
+			options: []ValueOption{WithMaxDepth(2)},
+			kind:    KindTooDeep,
+			offset:  8,
+		},
+		{
+			desc:   "too deep by default",
+			raw:    strings.Repeat("[", 1001) + strings.Repeat("]", 1001),
+			kind:   KindTooDeep,
+			offset: 1000,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ValueTree(test.raw, false, test.options...)
+
+			var syntaxErr *SyntaxError
+			require.ErrorAs(t, err, &syntaxErr)
+
+			assert.Equal(t, test.kind, syntaxErr.Kind)
+			assert.Equal(t, test.offset, syntaxErr.Offset)
+
+			if test.kind != KindUnbalancedBracket {
+				return
+			}
+
+			// Same error with Value.
+			_, err = Value(test.raw, false, WithBrackets())
+			require.ErrorIs(t, err, ErrUnbalancedBracket)
+		})
+	}
+}
+
+func TestValueTree_deep(t *testing.T) {
+	depth := 20000
+
+	raw := strings.Repeat("[", depth) + "a" + strings.Repeat("]", depth)
+
+	nodes, err := ValueTree(raw, false, WithMaxDepth(depth))
+	require.NoError(t, err)
+
+	for range depth {
+		require.Len(t, nodes, 1)
+		require.True(t, nodes[0].IsGroup())
+
+		nodes = nodes[0].Children
+	}
+
+	assert.Equal(t, []ValueNode{{Value: "a", Span: Span{Start: depth, End: depth + 1}}}, nodes)
+
+	_, err = ValueTree(raw, false)
+	require.ErrorIs(t, err, ErrTooDeep)
+	require.ErrorIs(t, err, ErrLimitExceeded)
+
+	_, err = Value(raw, false, WithBrackets(), WithMaxDepth(10))
+	require.ErrorIs(t, err, ErrTooDeep)
+}
+
+func BenchmarkValueTree_deep(b *testing.B) {
+	raw := strings.Repeat("[", 10000) + strings.Repeat("]", 10000)
+
+	b.ReportAllocs()
+
+	for b.Loop() {
+		_, err := ValueTree(raw, false, WithMaxDepth(10000))
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
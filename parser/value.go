@@ -1,83 +1,379 @@
 package parser
 
-import "fmt"
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// valueConfig for the value parser.
+type valueConfig struct {
+	// MaxValues is the maximum number of values (0 means no limit).
+	MaxValues int
+
+	// MaxValueLength is the maximum length of a value in bytes (0 means no limit).
+	MaxValueLength int
+
+	// Unescape removes the escape characters from the values.
+	Unescape bool
+
+	// Separator is the separator of the values.
+	Separator byte
+
+	// Escape enables the escaping of the separator.
+	Escape bool
+
+	// EscapeChar is the escape character.
+	EscapeChar byte
+
+	// SingleQuotes handles the single-quoted values as one value.
+	SingleQuotes bool
+
+	// Brackets are the pairs of brackets grouping values (e.g., "[]()").
+	Brackets string
+
+	// MaxDepth is the maximum nesting of the brackets (0 means no limit).
+	MaxDepth int
+
+	// Keys are the options of specific keys (see [ForKeys]).
+	Keys []keyOptions
+}
+
+// newValueConfig creates the default configuration: comma separator, and backslash escape character.
+func newValueConfig(escapeComma bool) valueConfig {
+	return valueConfig{
+		Separator:  ',',
+		Escape:     escapeComma,
+		EscapeChar: '\\',
+	}
+}
+
+// ValueOption configures [Value].
+type ValueOption func(*valueConfig)
+
+// WithMaxValues limits the number of values ([KindTooManyValues]).
+func WithMaxValues(n int) ValueOption {
+	return func(cfg *valueConfig) {
+		cfg.MaxValues = n
+	}
+}
+
+// WithMaxValueLength limits the length of each value in bytes ([KindValueTooLong]).
+func WithMaxValueLength(n int) ValueOption {
+	return func(cfg *valueConfig) {
+		cfg.MaxValueLength = n
+	}
+}
+
+// WithUnescape removes the escape backslashes from the values when the comma is escaped:
+// `\,` becomes `,` and `\\` becomes `\`, the other backslashes are kept.
+// [JoinEscaped] does the opposite.
+// The same applies with [WithSeparator] and [WithEscapeChar].
+func WithUnescape() ValueOption {
+	return func(cfg *valueConfig) {
+		cfg.Unescape = true
+	}
+}
+
+// WithSeparator sets the separator of the values (comma by default).
+// For example, `;` (GORM), ` ` (validator `oneof`), or `|` (validator alternation).
+func WithSeparator(sep byte) ValueOption {
+	return func(cfg *valueConfig) {
+		cfg.Separator = sep
+	}
+}
+
+// WithEscapeChar sets the escape character of the separator (backslash by default), and enables the escaping.
+func WithEscapeChar(c byte) ValueOption {
+	return func(cfg *valueConfig) {
+		cfg.EscapeChar = c
+		cfg.Escape = true
+	}
+}
+
+// WithEscapeComma enables the escaping of the separator with the escape character (like escapeComma of [Value]).
+// Combined with [ForKeys], the escaping can be enabled only for some keys.
+func WithEscapeComma() ValueOption {
+	return func(cfg *valueConfig) {
+		cfg.Escape = true
+	}
+}
+
+// WithSingleQuotes handles a single-quoted value as one value (encoding/json/v2 style names):
+// the separators inside the quotes are ignored, and the value is unquoted with the Go string rules
+// (e.g., `'a,b',omitempty` is split into `a,b` and `omitempty`).
+//
+// A single-quoted value must be followed by a separator or by the end of the value ([KindTextAfterQuote]),
+// and must be terminated ([KindMissingClosingQuote]) with valid escape sequences ([KindBadEscape]).
+func WithSingleQuotes() ValueOption {
+	return func(cfg *valueConfig) {
+		cfg.SingleQuotes = true
+	}
+}
 
 // Value parses a tag value.
-// The value is split on comma, and escaped commas are ignored.
-func Value(raw string, escapeComma bool) ([]string, error) {
-	if raw == "" {
-		return []string{""}, nil
+// The value is split on comma (see [WithSeparator]), and escaped commas are ignored.
+// The limit errors are returned as [*SyntaxError].
+//
+// The options of specific keys ([ForKeys]) are ignored, see [KeyValue].
+func Value(raw string, escapeComma bool, options ...ValueOption) ([]string, error) {
+	return KeyValue("", raw, escapeComma, options...)
+}
+
+// splitValue splits a raw value.
+func splitValue(raw string, cfg valueConfig) ([]string, error) {
+	// The values are counted first to allocate the slice once.
+	size := cfg.countValues(raw)
+	if cfg.MaxValues > 0 {
+		size = min(size, cfg.MaxValues)
 	}
 
-	var values []string
+	values := make([]string, 0, size)
 
-	for raw != "" {
-		i := indexEscaped(raw, 0, escapeComma)
+	err := cfg.split(raw, 0, len(raw), func(pos, n int) error {
+		value, err := cfg.value(raw, pos, n)
+		if err != nil {
+			return err
+		}
 
-		if i == 0 {
-			values = append(values, "")
-			raw = raw[i+1:]
+		values = append(values, value)
 
-			if raw == "" {
-				values = append(values, "")
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-				break
-			}
+	return values, nil
+}
 
-			continue
+// split calls fn with the offset and the length of each value of raw[start:end].
+func (cfg *valueConfig) split(raw string, start, end int, fn func(pos, n int) error) error {
+	count := 0
+
+	// pos is the offset of the remaining value inside the raw value.
+	pos := start
+
+	for {
+		i := cfg.segmentLength(raw[pos:end])
+
+		if cfg.MaxValues > 0 && count >= cfg.MaxValues {
+			return &SyntaxError{Kind: KindTooManyValues, Tag: raw, Offset: pos}
 		}
 
-		if i == len(raw) {
-			values = append(values, raw[:i])
+		if cfg.MaxValueLength > 0 && i > cfg.MaxValueLength {
+			return &SyntaxError{Kind: KindValueTooLong, Tag: raw, Offset: pos + cfg.MaxValueLength}
+		}
 
-			break
+		if err := cfg.checkBrackets(raw, pos, pos+i); err != nil {
+			return err
 		}
 
-		if i > len(raw) {
-			return nil, fmt.Errorf("syntax error in struct tag value %q", raw)
+		if err := fn(pos, i); err != nil {
+			return err
+		}
+
+		count++
+
+		pos += i
+		if pos >= end {
+			return nil
 		}
 
-		value := raw[:i]
+		// Skip the separator.
+		pos++
+	}
+}
 
-		endComma := raw[i] == ',' && raw[i+1:] == ""
+// value returns the value raw[pos:pos+n], unquoted or unescaped.
+func (cfg *valueConfig) value(raw string, pos, n int) (string, error) {
+	value := raw[pos : pos+n]
 
-		raw = raw[i+1:]
+	switch {
+	case cfg.isQuoted(value):
+		return unquoteSingle(raw, pos, n)
 
-		values = append(values, value)
+	case cfg.Escape && cfg.Unescape:
+		return cfg.unescape(value), nil
+
+	default:
+		return value, nil
+	}
+}
+
+// countValues returns the number of values of a raw value.
+func (cfg *valueConfig) countValues(raw string) int {
+	count := 1
 
-		if endComma {
-			values = append(values, "")
+	for pos := cfg.segmentLength(raw); pos < len(raw); pos += 1 + cfg.segmentLength(raw[pos+1:]) {
+		count++
+	}
+
+	return count
+}
+
+// segmentLength returns the length of the first value of a raw value.
+func (cfg *valueConfig) segmentLength(raw string) int {
+	if !cfg.isQuoted(raw) {
+		return cfg.indexSeparator(raw)
+	}
+
+	end := indexClosingQuote(raw)
+	if end < 0 {
+		return len(raw)
+	}
+
+	return end + 1 + cfg.indexSeparator(raw[end+1:])
+}
+
+// isQuoted reports whether a value starts with a single quote (see [WithSingleQuotes]).
+func (cfg *valueConfig) isQuoted(raw string) bool {
+	return cfg.SingleQuotes && raw != "" && raw[0] == '\''
+}
+
+// indexClosingQuote returns the index of the closing single quote of a single-quoted value, or -1 if there is none.
+func indexClosingQuote(raw string) int {
+	for i := 1; i < len(raw); i++ {
+		switch raw[i] {
+		case '\\':
+			// Skip the escaped character.
+			i++
+
+		case '\'':
+			return i
 		}
 	}
 
-	return values, nil
+	return -1
 }
 
-func indexEscaped(raw string, i int, escapeComma bool) int {
-	for i < len(raw) && raw[i] != ',' {
-		i++
+// unquoteSingle unquotes the single-quoted value raw[pos:pos+n] with the Go string rules.
+// Like encoding/json/v2, the value is converted to a double-quoted string: `\'` becomes `'`, and `"` becomes `\"`.
+func unquoteSingle(raw string, pos, n int) (string, error) {
+	segment := raw[pos : pos+n]
+
+	end := indexClosingQuote(segment)
+
+	switch {
+	case end < 0:
+		return "", &SyntaxError{Kind: KindMissingClosingQuote, Tag: raw, Offset: pos}
+
+	case end != len(segment)-1:
+		return "", &SyntaxError{Kind: KindTextAfterQuote, Tag: raw, Offset: pos + end + 1}
 	}
 
-	if !escapeComma {
-		return i
+	quoted := segment[1:end]
+
+	// Fast path: same result as strconv.Unquote.
+	if !strings.ContainsAny(quoted, "\\\"\n") && utf8.ValidString(quoted) {
+		return quoted, nil
 	}
 
-	if i-1 >= 0 && raw[i-1] == '\\' {
-		j := i - 1
+	var b strings.Builder
+
+	b.Grow(len(segment) + 2)
+	b.WriteByte('"')
+
+	for i := 0; i < len(quoted); i++ {
+		switch {
+		case quoted[i] == '\\' && i+1 < len(quoted) && quoted[i+1] == '\'':
+			i++
 
-		count := 1
+		case quoted[i] == '\\' && i+1 < len(quoted):
+			b.WriteByte(quoted[i])
+			i++
 
-		for j >= 0 && raw[j] == '\\' {
-			count++
-			j--
+		case quoted[i] == '"':
+			b.WriteByte('\\')
 		}
 
-		if count%2 == 0 {
+		b.WriteByte(quoted[i])
+	}
+
+	b.WriteByte('"')
+
+	value, err := strconv.Unquote(b.String())
+	if err != nil {
+		return "", &SyntaxError{Kind: KindBadEscape, Tag: raw, Offset: pos, Err: err}
+	}
+
+	return value, nil
+}
+
+// indexSeparator returns the index of the first separator (not escaped if the escaping is enabled, and not inside brackets),
+// or the length of the value if there is none.
+// A separator is escaped if it is preceded by an odd number of escape characters.
+func (cfg *valueConfig) indexSeparator(raw string) int {
+	// depth is the number of opened brackets (see [WithBrackets]).
+	depth := 0
+
+	for i := 0; i < len(raw); i++ {
+		switch {
+		case cfg.Escape && raw[i] == cfg.EscapeChar:
+			// Skip the escaped character.
+			i++
+
+		case depth == 0 && raw[i] == cfg.Separator:
+			return i
+
+		case cfg.opening(raw[i]) >= 0:
+			depth++
+
+		case depth > 0 && cfg.closing(raw[i]) >= 0:
+			depth--
+		}
+	}
+
+	return len(raw)
+}
+
+// unescape removes the escape characters before a separator or an escape character.
+func (cfg *valueConfig) unescape(value string) string {
+	if strings.IndexByte(value, cfg.EscapeChar) < 0 {
+		return value
+	}
+
+	var b strings.Builder
+
+	b.Grow(len(value))
+
+	for i := 0; i < len(value); i++ {
+		if value[i] == cfg.EscapeChar && i+1 < len(value) && (value[i+1] == cfg.Separator || value[i+1] == cfg.EscapeChar) {
 			i++
+		}
+
+		b.WriteByte(value[i])
+	}
+
+	return b.String()
+}
+
+// JoinEscaped joins values with a comma, and escapes the commas and the backslashes of the values.
+// The result can be split by [Value] with the comma escaped and [WithUnescape].
+//
+// [WithSeparator] and [WithEscapeChar] change the separator and the escape character, the other options are ignored.
+func JoinEscaped(values []string, options ...ValueOption) string {
+	cfg := newValueConfig(true)
+
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	var b strings.Builder
+
+	for i, value := range values {
+		if i > 0 {
+			b.WriteByte(cfg.Separator)
+		}
+
+		for j := range len(value) {
+			if value[j] == cfg.Separator || value[j] == cfg.EscapeChar {
+				b.WriteByte(cfg.EscapeChar)
+			}
 
-			return indexEscaped(raw, i, escapeComma)
+			b.WriteByte(value[j])
 		}
 	}
 
-	return i
+	return b.String()
 }
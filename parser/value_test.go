@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -114,3 +115,403 @@ func TestParseValue(t *testing.T) {
 		})
 	}
 }
+
+func TestParseValue_options(t *testing.T) {
+	values, err := Value("a,bb,ccc", false, WithMaxValues(3), WithMaxValueLength(3))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"a", "bb", "ccc"}, values)
+}
+
+func TestParseValue_options_error(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		raw      string
+		options  []ValueOption
+		kind     ErrorKind
+		offset   int
+		expected string
+	}{
+		{
+			desc:     "too many values",
+			raw:      "a,b,c",
+			options:  []ValueOption{WithMaxValues(2)},
+			kind:     KindTooManyValues,
+			offset:   4,
+			expected: "invalid struct tag value `a,b,c`: too many values",
+		},
+		{
+			desc:     "too many empty values",
+			raw:      ",,",
+			options:  []ValueOption{WithMaxValues(2)},
+			kind:     KindTooManyValues,
+			offset:   2,
+			expected: "invalid struct tag value `,,`: too many values",
+		},
+		{
+			desc:     "value too long",
+			raw:      "a,bbbb,c",
+			options:  []ValueOption{WithMaxValueLength(3)},
+			kind:     KindValueTooLong,
+			offset:   5,
+			expected: "invalid struct tag value `a,bbbb,c`: value too long",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Value(test.raw, true, test.options...)
+			require.EqualError(t, err, test.expected)
+
+			require.ErrorIs(t, err, ErrLimitExceeded)
+			require.ErrorIs(t, err, test.kind.sentinel())
+
+			var syntaxErr *SyntaxError
+			require.ErrorAs(t, err, &syntaxErr)
+
+			assert.Equal(t, test.kind, syntaxErr.Kind)
+			assert.Equal(t, test.offset, syntaxErr.Offset)
+		})
+	}
+}
+
+func TestParseValue_many_escaped_commas(t *testing.T) {
+	raw := strings.Repeat(`\\\,`, 1_000_000) + ",a"
+
+	values, err := Value(raw, true)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{raw[:len(raw)-2], "a"}, values)
+}
+
+func TestParseValue_unescape(t *testing.T) {
+	testCases := []struct {
+		desc               string
+		raw                string
+		expected           []string
+		expectedNotEscaped []string
+	}{
+		{
+			desc:               "no escape",
+			raw:                "a,b",
+			expected:           []string{"a", "b"},
+			expectedNotEscaped: []string{"a", "b"},
+		},
+		{
+			desc:               "escaped comma",
+			raw:                `e,f\,g`,
+			expected:           []string{"e", "f,g"},
+			expectedNotEscaped: []string{"e", `f\`, "g"},
+		},
+		{
+			desc:               "escaped backslash",
+			raw:                `a\\,b`,
+			expected:           []string{`a\`, "b"},
+			expectedNotEscaped: []string{`a\\`, "b"},
+		},
+		{
+			desc:               "escaped backslash and comma",
+			raw:                `a\\\,b`,
+			expected:           []string{`a\,b`},
+			expectedNotEscaped: []string{`a\\\`, "b"},
+		},
+		{
+			desc:               "other backslash",
+			raw:                `^\d+$`,
+			expected:           []string{`^\d+$`},
+			expectedNotEscaped: []string{`^\d+$`},
+		},
+		{
+			desc:               "trailing backslash",
+			raw:                `a\`,
+			expected:           []string{`a\`},
+			expectedNotEscaped: []string{`a\`},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			values, err := Value(test.raw, true, WithUnescape())
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, values)
+
+			// Without escaped comma, there is nothing to unescape.
+			values, err = Value(test.raw, false, WithUnescape())
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedNotEscaped, values)
+		})
+	}
+}
+
+func TestJoinEscaped(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		values   []string
+		expected string
+	}{
+		{
+			desc:     "empty",
+			expected: "",
+		},
+		{
+			desc:     "simple values",
+			values:   []string{"a", "omitempty"},
+			expected: "a,omitempty",
+		},
+		{
+			desc:     "comma",
+			values:   []string{"e", "f,g"},
+			expected: `e,f\,g`,
+		},
+		{
+			desc:     "backslash",
+			values:   []string{`a\`, `^\d+$`},
+			expected: `a\\,^\\d+$`,
+		},
+		{
+			desc:     "empty values",
+			values:   []string{"", ""},
+			expected: ",",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			raw := JoinEscaped(test.values)
+
+			assert.Equal(t, test.expected, raw)
+
+			if len(test.values) == 0 {
+				return
+			}
+
+			// Round trip.
+			values, err := Value(raw, true, WithUnescape())
+			require.NoError(t, err)
+
+			assert.Equal(t, test.values, values)
+		})
+	}
+}
+
+func TestParseValue_separator(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		raw         string
+		escapeComma bool
+		options     []ValueOption
+		expected    []string
+	}{
+		{
+			desc:     "semicolon",
+			raw:      "column:name;type:varchar(100),unique",
+			options:  []ValueOption{WithSeparator(';')},
+			expected: []string{"column:name", "type:varchar(100),unique"},
+		},
+		{
+			desc:     "space",
+			raw:      "red green  blue",
+			options:  []ValueOption{WithSeparator(' ')},
+			expected: []string{"red", "green", "", "blue"},
+		},
+		{
+			desc:     "pipe",
+			raw:      "email|url",
+			options:  []ValueOption{WithSeparator('|')},
+			expected: []string{"email", "url"},
+		},
+		{
+			desc:        "escaped separator",
+			raw:         `a\;b;c`,
+			escapeComma: true,
+			options:     []ValueOption{WithSeparator(';')},
+			expected:    []string{`a\;b`, "c"},
+		},
+		{
+			desc:     "escape character",
+			raw:      `a^|b|c`,
+			options:  []ValueOption{WithSeparator('|'), WithEscapeChar('^')},
+			expected: []string{`a^|b`, "c"},
+		},
+		{
+			desc:     "unescape",
+			raw:      `a^|b|c^^`,
+			options:  []ValueOption{WithSeparator('|'), WithEscapeChar('^'), WithUnescape()},
+			expected: []string{"a|b", "c^"},
+		},
+		{
+			desc:     "backslash with escape character",
+			raw:      `a\|b`,
+			options:  []ValueOption{WithSeparator('|'), WithEscapeChar('^')},
+			expected: []string{`a\`, "b"},
+		},
+		{
+			desc:     "limits",
+			raw:      "a;bb",
+			options:  []ValueOption{WithSeparator(';'), WithMaxValues(2), WithMaxValueLength(2)},
+			expected: []string{"a", "bb"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			values, err := Value(test.raw, test.escapeComma, test.options...)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, values)
+		})
+	}
+}
+
+func TestJoinEscaped_separator(t *testing.T) {
+	values := []string{"a|b", "c^", "d,e"}
+
+	raw := JoinEscaped(values, WithSeparator('|'), WithEscapeChar('^'))
+
+	assert.Equal(t, `a^|b|c^^|d,e`, raw)
+
+	// Round trip.
+	parsed, err := Value(raw, false, WithSeparator('|'), WithEscapeChar('^'), WithUnescape())
+	require.NoError(t, err)
+
+	assert.Equal(t, values, parsed)
+}
+
+func TestParseValue_singleQuotes(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		raw         string
+		escapeComma bool
+		options     []ValueOption
+		expected    []string
+	}{
+		{
+			desc:     "quoted name",
+			raw:      `'a,b',omitempty`,
+			expected: []string{"a,b", "omitempty"},
+		},
+		{
+			desc:     "quoted option",
+			raw:      `name,'x,y'`,
+			expected: []string{"name", "x,y"},
+		},
+		{
+			desc:     "empty quoted value",
+			raw:      `'',omitempty`,
+			expected: []string{"", "omitempty"},
+		},
+		{
+			desc:     "escaped single quote",
+			raw:      `'it\'s',omitempty`,
+			expected: []string{"it's", "omitempty"},
+		},
+		{
+			desc:     "double quote",
+			raw:      `'a"b'`,
+			expected: []string{`a"b`},
+		},
+		{
+			desc:     "escape sequences",
+			raw:      `'é\t\\'`,
+			expected: []string{"é\t\\"},
+		},
+		{
+			desc:     "quote inside a value",
+			raw:      `a'b,c'`,
+			expected: []string{"a'b", "c'"},
+		},
+		{
+			desc:        "escaped comma outside the quotes",
+			raw:         `'a,b',c\,d`,
+			escapeComma: true,
+			options:     []ValueOption{WithUnescape()},
+			expected:    []string{"a,b", "c,d"},
+		},
+		{
+			desc:     "separator",
+			raw:      `'a;b';c`,
+			options:  []ValueOption{WithSeparator(';')},
+			expected: []string{"a;b", "c"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			options := append([]ValueOption{WithSingleQuotes()}, test.options...)
+
+			values, err := Value(test.raw, test.escapeComma, options...)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, values)
+		})
+	}
+}
+
+func TestParseValue_singleQuotes_disabled(t *testing.T) {
+	values, err := Value(`'a,b',omitempty`, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"'a", "b'", "omitempty"}, values)
+}
+
+func TestParseValue_singleQuotes_error(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		raw    string
+		kind   ErrorKind
+		offset int
+	}{
+		{
+			desc:   "missing closing quote",
+			raw:    `name,'a,b`,
+			kind:   KindMissingClosingQuote,
+			offset: 5,
+		},
+		{
+			desc:   "text after quote",
+			raw:    `'a'b,c`,
+			kind:   KindTextAfterQuote,
+			offset: 3,
+		},
+		{
+			desc:   "bad escape",
+			raw:    `'\x',c`,
+			kind:   KindBadEscape,
+			offset: 0,
+		},
+		{
+			desc:   "newline",
+			raw:    "'a\nb'",
+			kind:   KindBadEscape,
+			offset: 0,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Value(test.raw, false, WithSingleQuotes())
+
+			var syntaxErr *SyntaxError
+			require.ErrorAs(t, err, &syntaxErr)
+
+			assert.Equal(t, test.kind, syntaxErr.Kind)
+			assert.Equal(t, test.offset, syntaxErr.Offset)
+
+			require.ErrorIs(t, err, test.kind.sentinel())
+		})
+	}
+}
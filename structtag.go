@@ -19,8 +19,8 @@ func ParseToMap(tag string, options ...mapsraw.Option) (mapsraw.Tag, error) {
 
 // ParseToMapMultikeys parses a struct tag to a `map[string][]string`.
 // For non-conventional tags where the key is repeated.
-func ParseToMapMultikeys(tag string) (mapsmultikeys.Tag, error) {
-	return mapsmultikeys.Parse(tag)
+func ParseToMapMultikeys(tag string, options ...mapsmultikeys.Option) (mapsmultikeys.Tag, error) {
+	return mapsmultikeys.Parse(tag, options...)
 }
 
 // ParseToMapValues parses a struct tag to a `map[string][]string`.
@@ -53,6 +53,6 @@ func ParseToStructured(tag string, options ...structured.Option) (*structured.Ta
 
 // ParseToFatih parses a struct tag to a [*structtag.Tags].
 // The value is split on comma.
-func ParseToFatih(tag string, escapeComma bool) (*structtag.Tags, error) {
-	return fatih.Parse(tag, escapeComma)
+func ParseToFatih(tag string, escapeComma bool, options ...fatih.Option) (*structtag.Tags, error) {
+	return fatih.Parse(tag, escapeComma, options...)
 }
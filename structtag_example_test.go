@@ -104,12 +104,12 @@ func ExampleParseToSlice() {
 	}
 
 	for _, datum := range data {
-		fmt.Println(datum)
+		fmt.Println(datum.Key, datum.Value)
 	}
 
 	// Output:
-	// {a 1,2}
-	// {b hello}
+	// a 1,2
+	// b hello
 }
 
 func ExampleParseToSliceValues() {
@@ -126,12 +126,12 @@ func ExampleParseToSliceValues() {
 	}
 
 	for _, datum := range data {
-		fmt.Println(datum)
+		fmt.Println(datum.Key, datum.Values)
 	}
 
 	// Output:
-	// {a [1 2]}
-	// {b [hello\ world]}
+	// a [1 2]
+	// b [hello\ world]
 }
 
 func ExampleParseToSliceValues_escaped_comma() {
@@ -148,12 +148,12 @@ func ExampleParseToSliceValues_escaped_comma() {
 	}
 
 	for _, datum := range data {
-		fmt.Println(datum)
+		fmt.Println(datum.Key, datum.Values)
 	}
 
 	// Output:
-	// {a [1 2]}
-	// {b [hello\,world]}
+	// a [1 2]
+	// b [hello\,world]
 }
 
 func ExampleParseToStructured() {
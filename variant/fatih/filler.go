@@ -6,24 +6,53 @@ import (
 )
 
 type Filler struct {
-	data        []*structtag.Tag
-	escapeComma bool
+	data         []*structtag.Tag
+	escapeComma  bool
+	valueOptions []parser.ValueOption
+
+	// duplicateKeys allows duplicate keys by default.
+	duplicateKeys parser.DuplicateKeysPolicy
 }
 
-func NewFiller(escapeComma bool) *Filler {
-	return &Filler{escapeComma: escapeComma}
+func NewFiller(escapeComma bool, duplicateKeys parser.DuplicateKeysPolicy, valueOptions ...parser.ValueOption) *Filler {
+	return &Filler{escapeComma: escapeComma, duplicateKeys: duplicateKeys, valueOptions: valueOptions}
 }
 
 func (f *Filler) Data() []*structtag.Tag {
 	return f.data
 }
 
+func (f *Filler) Reset() {
+	f.data = f.data[:0]
+}
+
 func (f *Filler) Fill(key, value string) error {
-	values, err := parser.Value(value, f.escapeComma)
+	first := f.get(key)
+
+	mode := DuplicateKeysAllow
+	if first != nil {
+		mode = parser.DuplicateKeyMode(f.duplicateKeys, key, DuplicateKeysAllow)
+	}
+
+	switch mode {
+	case DuplicateKeysIgnore:
+		return nil
+
+	case DuplicateKeysDeny:
+		return parser.NewDuplicateKeyError(key)
+	}
+
+	values, err := parser.KeyValue(key, value, f.escapeComma, f.valueOptions...)
 	if err != nil {
 		return err
 	}
 
+	if mode == DuplicateKeysMerge {
+		first.Options = append(first.Options, values...)
+
+		return nil
+	}
+
 	name := values[0]
 
 	options := values[1:]
@@ -31,6 +60,13 @@ func (f *Filler) Fill(key, value string) error {
 		options = nil
 	}
 
+	if mode == DuplicateKeysLastWins {
+		first.Name = name
+		first.Options = options
+
+		return nil
+	}
+
 	f.data = append(f.data, &structtag.Tag{
 		Key:     key,
 		Name:    name,
@@ -39,3 +75,14 @@ func (f *Filler) Fill(key, value string) error {
 
 	return nil
 }
+
+// get returns the first tag with the given key.
+func (f *Filler) get(key string) *structtag.Tag {
+	for _, tag := range f.data {
+		if tag.Key == key {
+			return tag
+		}
+	}
+
+	return nil
+}
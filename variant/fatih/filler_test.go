@@ -9,7 +9,7 @@ import (
 )
 
 func TestFiller_Fill(t *testing.T) {
-	filler := NewFiller(false)
+	filler := NewFiller(false, nil)
 
 	err := filler.Fill("a", "b")
 	require.NoError(t, err)
@@ -30,7 +30,7 @@ func TestFiller_Fill(t *testing.T) {
 }
 
 func TestFiller_Fill_escapeComma(t *testing.T) {
-	filler := NewFiller(true)
+	filler := NewFiller(true, nil)
 
 	err := filler.Fill("a", "b")
 	require.NoError(t, err)
@@ -51,7 +51,7 @@ func TestFiller_Fill_escapeComma(t *testing.T) {
 }
 
 func TestFiller_Fill_duplicate(t *testing.T) {
-	filler := NewFiller(false)
+	filler := NewFiller(false, nil)
 
 	err := filler.Fill("a", "b")
 	require.NoError(t, err)
@@ -66,3 +66,19 @@ func TestFiller_Fill_duplicate(t *testing.T) {
 
 	assert.Equal(t, expected, filler.Data())
 }
+
+func TestFiller_Fill_duplicatePolicy(t *testing.T) {
+	filler := NewFiller(false, DuplicateKeysIgnore)
+
+	err := filler.Fill("a", "b")
+	require.NoError(t, err)
+
+	err = filler.Fill("a", "c")
+	require.NoError(t, err)
+
+	expected := []*structtag.Tag{
+		{Key: "a", Name: "b"},
+	}
+
+	assert.Equal(t, expected, filler.Data())
+}
@@ -1,20 +1,101 @@
 package fatih
 
 import (
+	"slices"
+
 	"github.com/fatih/structtag"
 	"github.com/ldez/structtags/parser"
 )
 
+// DuplicateKeysMode defines how the duplicate keys are handled (see [parser.DuplicateKeysMode]).
+type DuplicateKeysMode = parser.DuplicateKeysMode
+
+const (
+	// DuplicateKeysIgnore skips silently duplicate keys.
+	DuplicateKeysIgnore = parser.DuplicateKeysIgnore
+
+	// DuplicateKeysDeny throws an error when duplicate keys are found.
+	DuplicateKeysDeny = parser.DuplicateKeysDeny
+
+	// DuplicateKeysAllow keeps all the duplicate keys in the [Filler].
+	// A [structtag.Tags] doesn't support duplicate keys: [Parse] handles it like [DuplicateKeysLastWins].
+	DuplicateKeysAllow = parser.DuplicateKeysAllow
+
+	// DuplicateKeysLastWins replaces the name and the options of the first key by the ones of the duplicate key (default, like [structtag.Tags.Set]).
+	DuplicateKeysLastWins = parser.DuplicateKeysLastWins
+
+	// DuplicateKeysMerge appends the name and the options of the duplicate key to the options of the first key.
+	DuplicateKeysMerge = parser.DuplicateKeysMerge
+)
+
+// config for the parser.
+type config struct {
+	// DuplicateKeys defines how the duplicate keys are handled.
+	DuplicateKeys parser.DuplicateKeysPolicy
+
+	// TagOptions are the options of the struct tag parser.
+	TagOptions []parser.TagOption
+
+	// ValueOptions are the options of the value parser.
+	ValueOptions []parser.ValueOption
+}
+
+type Option func(*config)
+
+// WithDuplicateKeysMode sets how the duplicate keys are handled ([DuplicateKeysLastWins] by default).
+func WithDuplicateKeysMode(mode DuplicateKeysMode) Option {
+	return func(opts *config) {
+		opts.DuplicateKeys = mode
+	}
+}
+
+// WithDuplicateKeysPolicy sets how the duplicate keys are handled, key by key (see [parser.DuplicateKeysPerKey]).
+func WithDuplicateKeysPolicy(policy parser.DuplicateKeysPolicy) Option {
+	return func(opts *config) {
+		opts.DuplicateKeys = policy
+	}
+}
+
+// WithTagOptions sets the options of the struct tag parser (see [parser.Tag]).
+func WithTagOptions(options ...parser.TagOption) Option {
+	return func(opts *config) {
+		opts.TagOptions = append(opts.TagOptions, options...)
+	}
+}
+
+// WithKeys only converts the pairs with the given keys to [structtag.Tag] (see [parser.WithKeys]).
+func WithKeys(keys ...string) Option {
+	return func(opts *config) {
+		opts.TagOptions = append(opts.TagOptions, parser.WithKeys(keys...))
+	}
+}
+
+// WithoutKeys doesn't convert the pairs with the given keys to [structtag.Tag] (see [parser.WithoutKeys]).
+func WithoutKeys(keys ...string) Option {
+	return func(opts *config) {
+		opts.TagOptions = append(opts.TagOptions, parser.WithoutKeys(keys...))
+	}
+}
+
+// WithValueOptions sets the options of the value parser (see [parser.Value]).
+func WithValueOptions(options ...parser.ValueOption) Option {
+	return func(opts *config) {
+		opts.ValueOptions = append(opts.ValueOptions, options...)
+	}
+}
+
 // Parse parses a struct tag to a [*structtag.Tags].
 // The value is split on comma.
-func Parse(tag string, escapeComma bool) (*structtag.Tags, error) {
-	tags, err := parser.Tag(tag, NewFiller(escapeComma))
-	if err != nil {
-		return nil, err
+func Parse(tag string, escapeComma bool, options ...Option) (*structtag.Tags, error) {
+	cfg := config{DuplicateKeys: DuplicateKeysLastWins}
+
+	for _, opt := range options {
+		opt(&cfg)
 	}
 
+	tags, err := parser.Tag(tag, NewFiller(escapeComma, cfg.DuplicateKeys, cfg.ValueOptions...), cfg.TagOptions...)
 	if len(tags) == 0 {
-		return nil, nil
+		return nil, err
 	}
 
 	ftgs := &structtag.Tags{}
@@ -25,5 +106,26 @@ func Parse(tag string, escapeComma bool) (*structtag.Tags, error) {
 		}
 	}
 
+	// The error is not nil only with partial data (parser.WithRecovery).
 	return ftgs, err
 }
+
+// Clone returns a deep copy of a [*structtag.Tags].
+// The copy is built with [structtag.Tags.Set]: the duplicate keys are not kept.
+func Clone(tags *structtag.Tags) *structtag.Tags {
+	if tags == nil {
+		return nil
+	}
+
+	c := &structtag.Tags{}
+
+	for _, tag := range tags.Tags() {
+		_ = c.Set(&structtag.Tag{
+			Key:     tag.Key,
+			Name:    tag.Name,
+			Options: slices.Clone(tag.Options),
+		})
+	}
+
+	return c
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/fatih/structtag"
+	"github.com/ldez/structtags/parser"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -193,3 +194,51 @@ func TestParse_extended(t *testing.T) {
 		})
 	}
 }
+
+func TestParse_options(t *testing.T) {
+	_, err := Parse(`a:"1"  b:"2"`, false, WithTagOptions(parser.WithStrict()))
+	require.ErrorIs(t, err, parser.ErrMultipleSpaces)
+
+	_, err = Parse(`a:"1,2"`, false, WithValueOptions(parser.WithMaxValues(1)))
+	require.ErrorIs(t, err, parser.ErrTooManyValues)
+}
+
+func TestParse_duplicateKeys(t *testing.T) {
+	// The last one wins by default, like structtag.Tags.Set.
+	tags, err := Parse(`a:"1" b:"2" a:"3,4"`, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, `a:"3,4" b:"2"`, tags.String())
+
+	tags, err = Parse(`a:"1" b:"2" a:"3,4"`, false, WithDuplicateKeysMode(DuplicateKeysMerge))
+	require.NoError(t, err)
+
+	assert.Equal(t, `a:"1,3,4" b:"2"`, tags.String())
+}
+
+func TestParse_withKeys(t *testing.T) {
+	// The values of the skipped pairs are not parsed.
+	tags, err := Parse(`a:"1,2" b:"'3"`, false, WithValueOptions(parser.WithSingleQuotes()), WithKeys("a"))
+	require.NoError(t, err)
+
+	assert.Equal(t, `a:"1,2"`, tags.String())
+}
+
+func TestClone(t *testing.T) {
+	tags, err := Parse(`json:"a,b" yaml:"c"`, false)
+	require.NoError(t, err)
+
+	c := Clone(tags)
+
+	tag, err := c.Get("json")
+	require.NoError(t, err)
+
+	tag.Name = "modified"
+	tag.Options[0] = "modified"
+	c.Delete("yaml")
+
+	assert.Equal(t, `json:"a,b" yaml:"c"`, tags.String())
+	assert.Equal(t, `json:"modified,modified"`, c.String())
+
+	assert.Nil(t, Clone(nil))
+}
@@ -1,23 +1,54 @@
 package multikeys
 
+import "github.com/ldez/structtags/parser"
+
 type Filler struct {
 	data Tag
+
+	// duplicateKeys allows duplicate keys by default.
+	duplicateKeys parser.DuplicateKeysPolicy
 }
 
-func NewFiller() *Filler {
-	return &Filler{}
+func NewFiller(duplicateKeys parser.DuplicateKeysPolicy) *Filler {
+	return &Filler{duplicateKeys: duplicateKeys}
 }
 
 func (f *Filler) Data() Tag {
 	return f.data
 }
 
+func (f *Filler) Reset() {
+	clear(f.data)
+}
+
 func (f *Filler) Fill(key, value string) error {
 	if f.data == nil {
 		f.data = Tag{}
 	}
 
-	f.data[key] = append(f.data[key], value)
+	values := f.data[key]
+
+	if len(values) > 0 {
+		switch parser.DuplicateKeyMode(f.duplicateKeys, key, DuplicateKeysAllow) {
+		case DuplicateKeysIgnore:
+			return nil
+
+		case DuplicateKeysDeny:
+			return parser.NewDuplicateKeyError(key)
+
+		case DuplicateKeysLastWins:
+			values[0] = value
+
+			return nil
+
+		case DuplicateKeysMerge:
+			values[0] = parser.MergeValues(key, values[0], value)
+
+			return nil
+		}
+	}
+
+	f.data[key] = append(values, value)
 
 	return nil
 }
@@ -3,12 +3,13 @@ package multikeys
 import (
 	"testing"
 
+	"github.com/ldez/structtags/parser"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func TestFiller_Fill(t *testing.T) {
-	filler := NewFiller()
+	filler := NewFiller(nil)
 
 	err := filler.Fill("a", "b")
 	require.NoError(t, err)
@@ -25,7 +26,7 @@ func TestFiller_Fill(t *testing.T) {
 }
 
 func TestFiller_Fill_duplicate(t *testing.T) {
-	filler := NewFiller()
+	filler := NewFiller(nil)
 
 	err := filler.Fill("a", "b")
 	require.NoError(t, err)
@@ -39,3 +40,13 @@ func TestFiller_Fill_duplicate(t *testing.T) {
 
 	assert.Equal(t, expected, filler.Data())
 }
+
+func TestFiller_Fill_duplicatePolicy(t *testing.T) {
+	filler := NewFiller(DuplicateKeysDeny)
+
+	err := filler.Fill("a", "b")
+	require.NoError(t, err)
+
+	err = filler.Fill("a", "c")
+	require.ErrorIs(t, err, parser.ErrDuplicateKey)
+}
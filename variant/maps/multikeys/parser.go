@@ -4,6 +4,26 @@ import "github.com/ldez/structtags/parser"
 
 // Parse parses a struct tag to a `map[string][]string`.
 // For non-conventional tags where the key is repeated.
-func Parse(tag string) (Tag, error) {
-	return parser.Tag(tag, NewFiller())
+func Parse(tag string, options ...Option) (Tag, error) {
+	var cfg config
+
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return parser.Tag(tag, NewFiller(cfg.DuplicateKeys), cfg.TagOptions...)
+}
+
+// NewPool creates a pool of reusable [Filler] with the [Parse] options (duplicate keys allowed by default).
+// The [Tag] map is cleared after each parsing: it is only valid during the callback of [parser.FillerPool.Parse].
+func NewPool(options ...Option) *parser.FillerPool[Tag] {
+	var cfg config
+
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return parser.NewFillerPool(func() parser.ResetFiller[Tag] {
+		return NewFiller(cfg.DuplicateKeys)
+	}, cfg.TagOptions...)
 }
@@ -3,6 +3,7 @@ package multikeys
 import (
 	"testing"
 
+	"github.com/ldez/structtags/parser"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -76,3 +77,54 @@ func TestParse(t *testing.T) {
 		})
 	}
 }
+
+func TestParse_options(t *testing.T) {
+	_, err := Parse(`a:"1"  b:"2"`, WithTagOptions(parser.WithStrict()))
+	require.ErrorIs(t, err, parser.ErrMultipleSpaces)
+}
+
+func TestNewPool(t *testing.T) {
+	pool := NewPool()
+
+	err := pool.Parse(`a:"1" a:"2"`, func(data Tag) error {
+		assert.Equal(t, Tag{"a": {"1", "2"}}, data)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	// The values of a reused filler don't contain the previous values.
+	err = pool.Parse(`a:"3"`, func(data Tag) error {
+		assert.Equal(t, Tag{"a": {"3"}}, data)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	err = pool.Parse(``, func(data Tag) error {
+		assert.Empty(t, data)
+
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestParse_duplicateKeys(t *testing.T) {
+	// Allowed by default.
+	tags, err := Parse(`a:"1" a:"2,3"`)
+	require.NoError(t, err)
+
+	assert.Equal(t, Tag{"a": {"1", "2,3"}}, tags)
+
+	tags, err = Parse(`a:"1" a:"2,3"`, WithDuplicateKeysMode(DuplicateKeysMerge))
+	require.NoError(t, err)
+
+	assert.Equal(t, Tag{"a": {"1,2,3"}}, tags)
+}
+
+func TestParse_withKeys(t *testing.T) {
+	tags, err := Parse(`a:"1" b:"2" a:"3"`, WithKeys("a"))
+	require.NoError(t, err)
+
+	assert.Equal(t, Tag{"a": {"1", "3"}}, tags)
+}
@@ -5,8 +5,76 @@ import (
 	"maps"
 	"slices"
 	"strings"
+
+	"github.com/ldez/structtags/parser"
 )
 
+// DuplicateKeysMode defines how the duplicate keys are handled (see [parser.DuplicateKeysMode]).
+type DuplicateKeysMode = parser.DuplicateKeysMode
+
+const (
+	// DuplicateKeysIgnore skips silently duplicate keys.
+	DuplicateKeysIgnore = parser.DuplicateKeysIgnore
+
+	// DuplicateKeysDeny throws an error when duplicate keys are found.
+	DuplicateKeysDeny = parser.DuplicateKeysDeny
+
+	// DuplicateKeysAllow keeps all the values of the duplicate keys (default).
+	DuplicateKeysAllow = parser.DuplicateKeysAllow
+
+	// DuplicateKeysLastWins replaces the value of the first key by the value of the duplicate key.
+	DuplicateKeysLastWins = parser.DuplicateKeysLastWins
+
+	// DuplicateKeysMerge appends the value of the duplicate key to the value of the first key, separated by a comma.
+	DuplicateKeysMerge = parser.DuplicateKeysMerge
+)
+
+// config for the parser.
+type config struct {
+	// DuplicateKeys defines how the duplicate keys are handled (allowed by default).
+	DuplicateKeys parser.DuplicateKeysPolicy
+
+	// TagOptions are the options of the struct tag parser.
+	TagOptions []parser.TagOption
+}
+
+type Option func(*config)
+
+// WithDuplicateKeysMode sets how the duplicate keys are handled.
+func WithDuplicateKeysMode(mode DuplicateKeysMode) Option {
+	return func(opts *config) {
+		opts.DuplicateKeys = mode
+	}
+}
+
+// WithDuplicateKeysPolicy sets how the duplicate keys are handled, key by key (see [parser.DuplicateKeysPerKey]).
+func WithDuplicateKeysPolicy(policy parser.DuplicateKeysPolicy) Option {
+	return func(opts *config) {
+		opts.DuplicateKeys = policy
+	}
+}
+
+// WithTagOptions sets the options of the struct tag parser (see [parser.Tag]).
+func WithTagOptions(options ...parser.TagOption) Option {
+	return func(opts *config) {
+		opts.TagOptions = append(opts.TagOptions, options...)
+	}
+}
+
+// WithKeys only collects the values of the given keys (see [parser.WithKeys]).
+func WithKeys(keys ...string) Option {
+	return func(opts *config) {
+		opts.TagOptions = append(opts.TagOptions, parser.WithKeys(keys...))
+	}
+}
+
+// WithoutKeys doesn't collect the values of the given keys (see [parser.WithoutKeys]).
+func WithoutKeys(keys ...string) Option {
+	return func(opts *config) {
+		opts.TagOptions = append(opts.TagOptions, parser.WithoutKeys(keys...))
+	}
+}
+
 // Tag is a key/values map.
 type Tag map[string][]string
 
@@ -25,3 +93,18 @@ func (m Tag) String() string {
 
 	return strings.TrimSuffix(b.String(), " ")
 }
+
+// Clone returns a deep copy of the [Tag].
+func (m Tag) Clone() Tag {
+	if m == nil {
+		return nil
+	}
+
+	c := make(Tag, len(m))
+
+	for k, v := range m {
+		c[k] = slices.Clone(v)
+	}
+
+	return c
+}
@@ -1,26 +1,36 @@
 package raw
 
-import "fmt"
+import "github.com/ldez/structtags/parser"
 
 type Filler struct {
 	data Tag
 
-	duplicateKeysMode DuplicateKeysMode
+	duplicateKeys parser.DuplicateKeysPolicy
 }
 
-func NewFiller(duplicateKeysMode DuplicateKeysMode) *Filler {
-	return &Filler{duplicateKeysMode: duplicateKeysMode}
+func NewFiller(duplicateKeys parser.DuplicateKeysPolicy) *Filler {
+	return &Filler{duplicateKeys: duplicateKeys}
 }
 
 func (f *Filler) Data() Tag {
 	return f.data
 }
 
+func (f *Filler) Reset() {
+	clear(f.data)
+}
+
 func (f *Filler) Fill(key, value string) error {
-	if f.data != nil && f.data[key] != "" {
-		switch f.duplicateKeysMode {
+	if _, ok := f.data[key]; ok {
+		switch parser.DuplicateKeyMode(f.duplicateKeys, key, DuplicateKeysIgnore) {
 		case DuplicateKeysDeny:
-			return fmt.Errorf("duplicate key %q", key)
+			return parser.NewDuplicateKeyError(key)
+
+		case DuplicateKeysLastWins:
+			// Do nothing.
+
+		case DuplicateKeysMerge:
+			value = parser.MergeValues(key, f.data[key], value)
 
 		default:
 			return nil
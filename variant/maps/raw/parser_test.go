@@ -1,8 +1,11 @@
 package raw
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/ldez/structtags/parser"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -71,4 +74,99 @@ func TestParse(t *testing.T) {
 func TestParse_options(t *testing.T) {
 	_, err := Parse(`a:"1" a:"2"`, WithDuplicateKeysMode(DuplicateKeysDeny))
 	require.EqualError(t, err, `duplicate key "a"`)
+	require.ErrorIs(t, err, parser.ErrDuplicateKey)
+
+	_, err = Parse(`a:"1"  b:"2"`, WithTagOptions(parser.WithStrict()))
+	require.ErrorIs(t, err, parser.ErrMultipleSpaces)
+}
+
+func TestNewPool(t *testing.T) {
+	pool := NewPool(WithDuplicateKeysMode(DuplicateKeysDeny))
+
+	err := pool.Parse(`a:"1" b:"2"`, func(data Tag) error {
+		assert.Equal(t, Tag{"a": "1", "b": "2"}, data)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	// The map of a reused filler is cleared.
+	err = pool.Parse(``, func(data Tag) error {
+		assert.Empty(t, data)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	err = pool.Parse(`a:"1" a:"2"`, func(_ Tag) error { return nil })
+	require.ErrorIs(t, err, parser.ErrDuplicateKey)
+}
+
+func TestParse_duplicateKeys(t *testing.T) {
+	// Ignored by default: the first value is kept, even if it is empty.
+	tags, err := Parse(`a:"" a:"x"`)
+	require.NoError(t, err)
+
+	assert.Equal(t, Tag{"a": ""}, tags)
+
+	tags, err = Parse(`a:"" a:"x"`, WithDuplicateKeysMode(DuplicateKeysMerge))
+	require.NoError(t, err)
+
+	assert.Equal(t, Tag{"a": ",x"}, tags)
+}
+
+func TestParse_withKeys(t *testing.T) {
+	// The skipped pairs are not duplicate keys.
+	tags, err := Parse(`a:"1" a:"2" b:"3"`, WithDuplicateKeysMode(DuplicateKeysDeny), WithoutKeys("a"))
+	require.NoError(t, err)
+
+	assert.Equal(t, Tag{"b": "3"}, tags)
+}
+
+func TestParse_reflectCompat(t *testing.T) {
+	tags, err := Parse(`a:"" a:"x"`, WithTagOptions(parser.WithReflectCompat()))
+	require.NoError(t, err)
+
+	assert.Equal(t, Tag{"a": ""}, tags)
+}
+
+// FuzzParse_reflectCompat checks the promise of [parser.WithReflectCompat] with the default [DuplicateKeysIgnore].
+func FuzzParse_reflectCompat(f *testing.F) {
+	seeds := []string{
+		``,
+		`json:"a"`,
+		`json:"a" yaml:"b"`,
+		`json:"a" json:"b"`,
+		`json:"" json:"b"`,
+		`json:"a" yaml:b xml:"c"`,
+		`json:"\q" yaml:"b" json:"c"`,
+		"json:\"a\"\tyaml:\"b\"",
+		"é:\"a\" \xff:\"b\"",
+	}
+
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, tag string) {
+		tags, err := Parse(tag, WithTagOptions(parser.WithReflectCompat()))
+		require.NoError(t, err)
+
+		keys := strings.FieldsFunc(tag, func(r rune) bool {
+			return r <= ' ' || r == ':' || r == '"' || r == 0x7f
+		})
+
+		for key := range tags {
+			keys = append(keys, key)
+		}
+
+		for _, key := range keys {
+			expected, expectedOK := reflect.StructTag(tag).Lookup(key)
+
+			value, ok := tags[key]
+
+			assert.Equal(t, expectedOK, ok, "key %q", key)
+			assert.Equal(t, expected, value, "key %q", key)
+		}
+	})
 }
@@ -5,29 +5,71 @@ import (
 	"maps"
 	"slices"
 	"strings"
+
+	"github.com/ldez/structtags/parser"
 )
 
-type DuplicateKeysMode int
+// DuplicateKeysMode defines how the duplicate keys are handled (see [parser.DuplicateKeysMode]).
+type DuplicateKeysMode = parser.DuplicateKeysMode
 
 const (
 	// DuplicateKeysIgnore skips silently duplicate keys.
-	DuplicateKeysIgnore DuplicateKeysMode = iota
+	DuplicateKeysIgnore = parser.DuplicateKeysIgnore
 
 	// DuplicateKeysDeny throws an error when duplicate keys are found.
-	DuplicateKeysDeny
+	DuplicateKeysDeny = parser.DuplicateKeysDeny
+
+	// DuplicateKeysLastWins replaces the value of the first key by the value of the duplicate key.
+	DuplicateKeysLastWins = parser.DuplicateKeysLastWins
+
+	// DuplicateKeysMerge appends the value of the duplicate key to the value of the first key, separated by a comma.
+	DuplicateKeysMerge = parser.DuplicateKeysMerge
 )
 
 // config for the parser.
 type config struct {
-	// DuplicateKeysMode allows duplicate keys.
-	DuplicateKeysMode DuplicateKeysMode
+	// DuplicateKeys defines how the duplicate keys are handled.
+	DuplicateKeys parser.DuplicateKeysPolicy
+
+	// TagOptions are the options of the struct tag parser.
+	TagOptions []parser.TagOption
 }
 
 type Option func(*config)
 
+// WithDuplicateKeysMode sets how the duplicate keys are handled.
+// [parser.DuplicateKeysAllow] is not supported by a map, and is handled like [DuplicateKeysIgnore].
 func WithDuplicateKeysMode(mode DuplicateKeysMode) Option {
 	return func(options *config) {
-		options.DuplicateKeysMode = mode
+		options.DuplicateKeys = mode
+	}
+}
+
+// WithDuplicateKeysPolicy sets how the duplicate keys are handled, key by key (see [parser.DuplicateKeysPerKey]).
+func WithDuplicateKeysPolicy(policy parser.DuplicateKeysPolicy) Option {
+	return func(options *config) {
+		options.DuplicateKeys = policy
+	}
+}
+
+// WithTagOptions sets the options of the struct tag parser (see [parser.Tag]).
+func WithTagOptions(options ...parser.TagOption) Option {
+	return func(opts *config) {
+		opts.TagOptions = append(opts.TagOptions, options...)
+	}
+}
+
+// WithKeys only adds the pairs with the given keys to the [Tag] map (see [parser.WithKeys]).
+func WithKeys(keys ...string) Option {
+	return func(opts *config) {
+		opts.TagOptions = append(opts.TagOptions, parser.WithKeys(keys...))
+	}
+}
+
+// WithoutKeys doesn't add the pairs with the given keys to the [Tag] map (see [parser.WithoutKeys]).
+func WithoutKeys(keys ...string) Option {
+	return func(opts *config) {
+		opts.TagOptions = append(opts.TagOptions, parser.WithoutKeys(keys...))
 	}
 }
 
@@ -47,3 +89,8 @@ func (m Tag) String() string {
 
 	return strings.TrimSuffix(b.String(), " ")
 }
+
+// Clone returns a copy of the [Tag].
+func (m Tag) Clone() Tag {
+	return maps.Clone(m)
+}
@@ -39,3 +39,13 @@ func TestTag_String(t *testing.T) {
 		})
 	}
 }
+
+func TestTag_Clone(t *testing.T) {
+	tag := Tag{"a": "b"}
+
+	c := tag.Clone()
+	c["a"] = "c"
+
+	assert.Equal(t, Tag{"a": "b"}, tag)
+	assert.Equal(t, Tag{"a": "c"}, c)
+}
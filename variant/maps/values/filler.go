@@ -1,22 +1,20 @@
 package values
 
-import (
-	"fmt"
-
-	"github.com/ldez/structtags/parser"
-)
+import "github.com/ldez/structtags/parser"
 
 type Filler struct {
 	data Tag
 
-	escapeComma       bool
-	duplicateKeysMode DuplicateKeysMode
+	escapeComma   bool
+	duplicateKeys parser.DuplicateKeysPolicy
+	valueOptions  []parser.ValueOption
 }
 
-func NewFiller(escapeComma bool, duplicateKeysMode DuplicateKeysMode) *Filler {
+func NewFiller(escapeComma bool, duplicateKeys parser.DuplicateKeysPolicy, valueOptions ...parser.ValueOption) *Filler {
 	return &Filler{
-		escapeComma:       escapeComma,
-		duplicateKeysMode: duplicateKeysMode,
+		escapeComma:   escapeComma,
+		duplicateKeys: duplicateKeys,
+		valueOptions:  valueOptions,
 	}
 }
 
@@ -24,15 +22,25 @@ func (f *Filler) Data() Tag {
 	return f.data
 }
 
+func (f *Filler) Reset() {
+	clear(f.data)
+}
+
 func (f *Filler) Fill(key, value string) error {
+	// replace is true when the values of the duplicate key replace the existing values.
+	replace := false
+
 	if f.data != nil && len(f.data[key]) > 0 {
-		switch f.duplicateKeysMode {
+		switch parser.DuplicateKeyMode(f.duplicateKeys, key, DuplicateKeysIgnore) {
 		case DuplicateKeysDeny:
-			return fmt.Errorf("duplicate key %q", key)
+			return parser.NewDuplicateKeyError(key)
 
-		case DuplicateKeysAllow:
+		case DuplicateKeysAllow, DuplicateKeysMerge:
 			// Do nothing.
 
+		case DuplicateKeysLastWins:
+			replace = true
+
 		case DuplicateKeysIgnore:
 			return nil
 
@@ -45,11 +53,17 @@ func (f *Filler) Fill(key, value string) error {
 		f.data = Tag{}
 	}
 
-	values, err := parser.Value(value, f.escapeComma)
+	values, err := parser.KeyValue(key, value, f.escapeComma, f.valueOptions...)
 	if err != nil {
 		return err
 	}
 
+	if replace {
+		f.data[key] = values
+
+		return nil
+	}
+
 	f.data[key] = append(f.data[key], values...)
 
 	return nil
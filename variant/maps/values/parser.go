@@ -12,5 +12,19 @@ func Parse(tag string, options ...Option) (Tag, error) {
 		opt(&cfg)
 	}
 
-	return parser.Tag(tag, NewFiller(cfg.EscapeComma, cfg.DuplicateKeysMode))
+	return parser.Tag(tag, NewFiller(cfg.EscapeComma, cfg.DuplicateKeys, cfg.ValueOptions...), cfg.TagOptions...)
+}
+
+// NewPool creates a pool of reusable [Filler] with the [Parse] options (escaping and value options included).
+// The [Tag] map is cleared after each parsing: it is only valid during the callback of [parser.FillerPool.Parse].
+func NewPool(options ...Option) *parser.FillerPool[Tag] {
+	var cfg config
+
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return parser.NewFillerPool(func() parser.ResetFiller[Tag] {
+		return NewFiller(cfg.EscapeComma, cfg.DuplicateKeys, cfg.ValueOptions...)
+	}, cfg.TagOptions...)
 }
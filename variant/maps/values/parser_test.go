@@ -3,6 +3,7 @@ package values
 import (
 	"testing"
 
+	"github.com/ldez/structtags/parser"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -75,4 +76,150 @@ func TestParse(t *testing.T) {
 func TestParse_options(t *testing.T) {
 	_, err := Parse(`a:"1" a:"2"`, WithDuplicateKeysMode(DuplicateKeysDeny))
 	require.EqualError(t, err, `duplicate key "a"`)
+	require.ErrorIs(t, err, parser.ErrDuplicateKey)
+
+	_, err = Parse(`a:"1"  b:"2"`, WithTagOptions(parser.WithStrict()))
+	require.ErrorIs(t, err, parser.ErrMultipleSpaces)
+
+	_, err = Parse(`a:"1,2"`, WithValueOptions(parser.WithMaxValues(1)))
+	require.ErrorIs(t, err, parser.ErrTooManyValues)
+}
+
+func TestParse_unescapeComma(t *testing.T) {
+	tags, err := Parse(`a:"e,f\\,g,h\\\\"`, WithUnescapeComma())
+	require.NoError(t, err)
+
+	assert.Equal(t, Tag{"a": {"e", "f,g", `h\`}}, tags)
+}
+
+func TestParse_separator(t *testing.T) {
+	tags, err := Parse(`gorm:"column:name;unique" validate:"oneof=a b"`, WithSeparator(';'))
+	require.NoError(t, err)
+
+	assert.Equal(t, Tag{"gorm": {"column:name", "unique"}, "validate": {"oneof=a b"}}, tags)
+
+	tags, err = Parse(`validate:"a|b^|c"`, WithSeparator('|'), WithEscapeChar('^'))
+	require.NoError(t, err)
+
+	assert.Equal(t, Tag{"validate": {"a", "b^|c"}}, tags)
+}
+
+func TestParse_singleQuotes(t *testing.T) {
+	tags, err := Parse(`json:"'a,b',omitempty"`, WithSingleQuotes())
+	require.NoError(t, err)
+
+	assert.Equal(t, Tag{"json": {"a,b", "omitempty"}}, tags)
+
+	_, err = Parse(`json:"'a,b"`, WithSingleQuotes())
+	require.ErrorIs(t, err, parser.ErrMissingClosingQuote)
+}
+
+func TestParse_valueError(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		tag     string
+		options []Option
+		kind    parser.ErrorKind
+		offset  int
+	}{
+		{
+			desc:    "too many values",
+			tag:     `json:"a" yaml:"1,2,3"`,
+			options: []Option{WithValueOptions(parser.WithMaxValues(2))},
+			kind:    parser.KindTooManyValues,
+			offset:  19,
+		},
+		{
+			desc:    "value too long",
+			tag:     `json:"a" yaml:"1,234"`,
+			options: []Option{WithValueOptions(parser.WithMaxValueLength(2))},
+			kind:    parser.KindValueTooLong,
+			offset:  19,
+		},
+		{
+			desc:    "missing single quote",
+			tag:     `json:"a" yaml:"1,'2"`,
+			options: []Option{WithSingleQuotes()},
+			kind:    parser.KindMissingClosingQuote,
+			offset:  17,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Parse(test.tag, test.options...)
+
+			var syntaxErr *parser.SyntaxError
+			require.ErrorAs(t, err, &syntaxErr)
+
+			// The error is positioned in the struct tag, not in the value.
+			assert.Equal(t, test.kind, syntaxErr.Kind)
+			assert.Equal(t, test.tag, syntaxErr.Tag)
+			assert.Equal(t, "yaml", syntaxErr.Key)
+			assert.Equal(t, test.offset, syntaxErr.Offset)
+		})
+	}
+}
+
+func TestNewPool(t *testing.T) {
+	pool := NewPool(WithEscapeCommaKeys("a"))
+
+	err := pool.Parse(`a:"1\\,2,3" b:"4\\,5"`, func(data Tag) error {
+		assert.Equal(t, Tag{"a": {`1\,2`, "3"}, "b": {`4\`, "5"}}, data)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	// The map of a reused filler is cleared.
+	err = pool.Parse(``, func(data Tag) error {
+		assert.Empty(t, data)
+
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestParse_duplicateKeys(t *testing.T) {
+	// Ignored by default.
+	tags, err := Parse(`a:"1" a:"2,3"`)
+	require.NoError(t, err)
+
+	assert.Equal(t, Tag{"a": {"1"}}, tags)
+
+	tags, err = Parse(`a:"1" a:"2,3"`, WithDuplicateKeysMode(DuplicateKeysMerge))
+	require.NoError(t, err)
+
+	assert.Equal(t, Tag{"a": {"1", "2", "3"}}, tags)
+}
+
+func TestParse_keys(t *testing.T) {
+	tags, err := Parse(`default:"a\\,b,c" json:"a\\,b,c"`,
+		WithEscapeCommaKeys("default", "env-default"),
+		WithValueOptions(parser.WithUnescape()),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, Tag{"default": {"a,b", "c"}, "json": {`a\`, "b", "c"}}, tags)
+
+	policy := WithDuplicateKeysPolicy(parser.DuplicateKeysPerKey{
+		Default: DuplicateKeysIgnore,
+		Keys:    map[string]DuplicateKeysMode{"json": DuplicateKeysDeny},
+	})
+
+	_, err = Parse(`default:"a" default:"b"`, policy)
+	require.NoError(t, err)
+
+	_, err = Parse(`json:"a" json:"b"`, policy)
+	require.ErrorIs(t, err, parser.ErrDuplicateKey)
+}
+
+func TestParse_withKeys(t *testing.T) {
+	// The values of the skipped pairs are not parsed.
+	tags, err := Parse(`a:"1,2" b:"'3"`, WithSingleQuotes(), WithKeys("a"))
+	require.NoError(t, err)
+
+	assert.Equal(t, Tag{"a": {"1", "2"}}, tags)
 }
@@ -5,19 +5,28 @@ import (
 	"maps"
 	"slices"
 	"strings"
+
+	"github.com/ldez/structtags/parser"
 )
 
-type DuplicateKeysMode int
+// DuplicateKeysMode defines how the duplicate keys are handled (see [parser.DuplicateKeysMode]).
+type DuplicateKeysMode = parser.DuplicateKeysMode
 
 const (
 	// DuplicateKeysIgnore skips silently duplicate keys.
-	DuplicateKeysIgnore DuplicateKeysMode = iota
+	DuplicateKeysIgnore = parser.DuplicateKeysIgnore
 
 	// DuplicateKeysDeny throws an error when duplicate keys are found.
-	DuplicateKeysDeny
+	DuplicateKeysDeny = parser.DuplicateKeysDeny
 
 	// DuplicateKeysAllow NOT RECOMMENDED: this does not follow the struct tag conventions.
-	DuplicateKeysAllow
+	DuplicateKeysAllow = parser.DuplicateKeysAllow
+
+	// DuplicateKeysLastWins replaces the value of the first key by the value of the duplicate key.
+	DuplicateKeysLastWins = parser.DuplicateKeysLastWins
+
+	// DuplicateKeysMerge appends the values of the duplicate key to the values of the first key.
+	DuplicateKeysMerge = parser.DuplicateKeysMerge
 )
 
 // config for the parser.
@@ -25,8 +34,14 @@ type config struct {
 	// EscapeComma is used to escape the comma character within the value.
 	EscapeComma bool
 
-	// DuplicateKeysMode allows duplicate keys.
-	DuplicateKeysMode DuplicateKeysMode
+	// DuplicateKeys defines how the duplicate keys are handled.
+	DuplicateKeys parser.DuplicateKeysPolicy
+
+	// TagOptions are the options of the struct tag parser.
+	TagOptions []parser.TagOption
+
+	// ValueOptions are the options of the value parser.
+	ValueOptions []parser.ValueOption
 }
 
 type Option func(*config)
@@ -37,9 +52,83 @@ func WithEscapeComma() Option {
 	}
 }
 
+// WithUnescapeComma escapes the comma (like [WithEscapeComma]),
+// and removes the escape backslashes from the values (see [parser.WithUnescape] and [parser.JoinEscaped]).
+func WithUnescapeComma() Option {
+	return func(opts *config) {
+		opts.EscapeComma = true
+		opts.ValueOptions = append(opts.ValueOptions, parser.WithUnescape())
+	}
+}
+
+// WithEscapeCommaKeys escapes the comma only in the values of the given keys (see [parser.ForKeys]).
+// For example, `default` and `env-default` honour the escapes, and `json` doesn't.
+func WithEscapeCommaKeys(keys ...string) Option {
+	return func(opts *config) {
+		opts.ValueOptions = append(opts.ValueOptions, parser.ForKeys(keys, parser.WithEscapeComma()))
+	}
+}
+
+// WithSeparator sets the separator of the values (see [parser.WithSeparator]).
+func WithSeparator(sep byte) Option {
+	return func(opts *config) {
+		opts.ValueOptions = append(opts.ValueOptions, parser.WithSeparator(sep))
+	}
+}
+
+// WithEscapeChar sets the escape character of the separator, and enables the escaping (see [parser.WithEscapeChar]).
+func WithEscapeChar(c byte) Option {
+	return func(opts *config) {
+		opts.EscapeComma = true
+		opts.ValueOptions = append(opts.ValueOptions, parser.WithEscapeChar(c))
+	}
+}
+
+// WithSingleQuotes handles a single-quoted value as one value (see [parser.WithSingleQuotes]).
+func WithSingleQuotes() Option {
+	return func(opts *config) {
+		opts.ValueOptions = append(opts.ValueOptions, parser.WithSingleQuotes())
+	}
+}
+
 func WithDuplicateKeysMode(mode DuplicateKeysMode) Option {
 	return func(opts *config) {
-		opts.DuplicateKeysMode = mode
+		opts.DuplicateKeys = mode
+	}
+}
+
+// WithDuplicateKeysPolicy sets how the duplicate keys are handled, key by key (see [parser.DuplicateKeysPerKey]).
+func WithDuplicateKeysPolicy(policy parser.DuplicateKeysPolicy) Option {
+	return func(opts *config) {
+		opts.DuplicateKeys = policy
+	}
+}
+
+// WithTagOptions sets the options of the struct tag parser (see [parser.Tag]).
+func WithTagOptions(options ...parser.TagOption) Option {
+	return func(opts *config) {
+		opts.TagOptions = append(opts.TagOptions, options...)
+	}
+}
+
+// WithKeys only adds the pairs with the given keys to the [Tag] map: the values of the other pairs are not split (see [parser.WithKeys]).
+func WithKeys(keys ...string) Option {
+	return func(opts *config) {
+		opts.TagOptions = append(opts.TagOptions, parser.WithKeys(keys...))
+	}
+}
+
+// WithoutKeys doesn't add the pairs with the given keys to the [Tag] map: their values are not split (see [parser.WithoutKeys]).
+func WithoutKeys(keys ...string) Option {
+	return func(opts *config) {
+		opts.TagOptions = append(opts.TagOptions, parser.WithoutKeys(keys...))
+	}
+}
+
+// WithValueOptions sets the options of the value parser (see [parser.Value]).
+func WithValueOptions(options ...parser.ValueOption) Option {
+	return func(opts *config) {
+		opts.ValueOptions = append(opts.ValueOptions, options...)
 	}
 }
 
@@ -59,3 +148,18 @@ func (m Tag) String() string {
 
 	return strings.TrimSuffix(b.String(), " ")
 }
+
+// Clone returns a deep copy of the [Tag].
+func (m Tag) Clone() Tag {
+	if m == nil {
+		return nil
+	}
+
+	c := make(Tag, len(m))
+
+	for k, v := range m {
+		c[k] = slices.Clone(v)
+	}
+
+	return c
+}
@@ -39,3 +39,15 @@ func TestTag_String(t *testing.T) {
 		})
 	}
 }
+
+func TestTag_Clone(t *testing.T) {
+	tag := Tag{"a": {"b", "c"}}
+
+	c := tag.Clone()
+	c["a"][0] = "d"
+
+	assert.Equal(t, Tag{"a": {"b", "c"}}, tag)
+	assert.Equal(t, Tag{"a": {"d", "c"}}, c)
+
+	assert.Nil(t, Tag(nil).Clone())
+}
@@ -1,19 +1,20 @@
 package raw
 
-import "fmt"
+import "github.com/ldez/structtags/parser"
 
 type Filler struct {
 	data Tags
 
-	keys map[string]struct{}
+	// keys are the indexes of the first entries of the keys.
+	keys map[string]int
 
-	duplicateKeysMode DuplicateKeysMode
+	duplicateKeys parser.DuplicateKeysPolicy
 }
 
-func NewFiller(duplicateKeysMode DuplicateKeysMode) *Filler {
+func NewFiller(duplicateKeys parser.DuplicateKeysPolicy) *Filler {
 	return &Filler{
-		keys:              map[string]struct{}{},
-		duplicateKeysMode: duplicateKeysMode,
+		keys:          map[string]int{},
+		duplicateKeys: duplicateKeys,
 	}
 }
 
@@ -21,25 +22,46 @@ func (f *Filler) Data() Tags {
 	return f.data
 }
 
+func (f *Filler) Reset() {
+	clear(f.keys)
+
+	f.data = f.data[:0]
+}
+
 func (f *Filler) Fill(key, value string) error {
-	if _, ok := f.keys[key]; ok {
-		switch f.duplicateKeysMode {
+	return f.FillSpans(key, value, parser.Spans{})
+}
+
+func (f *Filler) FillSpans(key, value string, spans parser.Spans) error {
+	if i, ok := f.keys[key]; ok {
+		switch parser.DuplicateKeyMode(f.duplicateKeys, key, DuplicateKeysIgnore) {
 		case DuplicateKeysIgnore:
 			return nil
 
 		case DuplicateKeysDeny:
-			return fmt.Errorf("duplicate key %q", key)
+			return parser.NewDuplicateKeyError(key)
 
 		case DuplicateKeysAllow:
 			// Do nothing.
+
+		case DuplicateKeysLastWins:
+			f.data[i] = Tag{Key: key, Value: value, Spans: spans}
+
+			return nil
+
+		case DuplicateKeysMerge:
+			f.data[i].Value = parser.MergeValues(key, f.data[i].Value, value)
+
+			return nil
 		}
+	} else {
+		f.keys[key] = len(f.data)
 	}
 
-	f.keys[key] = struct{}{}
-
 	f.data = append(f.data, Tag{
 		Key:   key,
 		Value: value,
+		Spans: spans,
 	})
 
 	return nil
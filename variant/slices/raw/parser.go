@@ -11,5 +11,19 @@ func Parse(tag string, options ...Option) (Tags, error) {
 		opt(&cfg)
 	}
 
-	return parser.Tag(tag, NewFiller(cfg.DuplicateKeysMode))
+	return parser.Tag(tag, NewFiller(cfg.DuplicateKeys), cfg.TagOptions...)
+}
+
+// NewPool creates a pool of reusable [Filler] with the [Parse] options.
+// The [Tags] slice is truncated after each parsing: it is only valid during the callback of [parser.FillerPool.Parse].
+func NewPool(options ...Option) *parser.FillerPool[Tags] {
+	var cfg config
+
+	for _, opt := range options {
+		opt(&cfg)
+	}
+
+	return parser.NewFillerPool(func() parser.ResetFiller[Tags] {
+		return NewFiller(cfg.DuplicateKeys)
+	}, cfg.TagOptions...)
 }
@@ -3,6 +3,7 @@ package raw
 import (
 	"testing"
 
+	"github.com/ldez/structtags/parser"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -21,44 +22,44 @@ func TestParse(t *testing.T) {
 		{
 			desc:     "empty value",
 			tag:      `json:""`,
-			expected: Tags{{Key: "json", Value: ""}},
+			expected: Tags{{Key: "json", Value: "", Spans: parser.Spans{Key: parser.Span{Start: 0, End: 4}, QuotedValue: parser.Span{Start: 5, End: 7}, Value: parser.Span{Start: 6, End: 6}}}},
 		},
 		{
 			desc:     "simple value",
 			tag:      `json:"a"`,
-			expected: Tags{{Key: "json", Value: "a"}},
+			expected: Tags{{Key: "json", Value: "a", Spans: parser.Spans{Key: parser.Span{Start: 0, End: 4}, QuotedValue: parser.Span{Start: 5, End: 8}, Value: parser.Span{Start: 6, End: 7}}}},
 		},
 		{
 			desc:     "multiple values",
 			tag:      `json:"a,b,c"`,
-			expected: Tags{{Key: "json", Value: "a,b,c"}},
+			expected: Tags{{Key: "json", Value: "a,b,c", Spans: parser.Spans{Key: parser.Span{Start: 0, End: 4}, QuotedValue: parser.Span{Start: 5, End: 12}, Value: parser.Span{Start: 6, End: 11}}}},
 		},
 		{
 			desc:     "quoted value",
 			tag:      `json:"a:\"b\""`,
-			expected: Tags{{Key: "json", Value: "a:\"b\""}},
+			expected: Tags{{Key: "json", Value: "a:\"b\"", Spans: parser.Spans{Key: parser.Span{Start: 0, End: 4}, QuotedValue: parser.Span{Start: 5, End: 14}, Value: parser.Span{Start: 6, End: 13}}}},
 		},
 		{
 			desc: "multiple empty tag",
 			tag:  `json:"" yaml:""`,
 			expected: Tags{
-				{Key: "json", Value: ""},
-				{Key: "yaml", Value: ""},
+				{Key: "json", Value: "", Spans: parser.Spans{Key: parser.Span{Start: 0, End: 4}, QuotedValue: parser.Span{Start: 5, End: 7}, Value: parser.Span{Start: 6, End: 6}}},
+				{Key: "yaml", Value: "", Spans: parser.Spans{Key: parser.Span{Start: 8, End: 12}, QuotedValue: parser.Span{Start: 13, End: 15}, Value: parser.Span{Start: 14, End: 14}}},
 			},
 		},
 		{
 			desc: "multiple tag",
 			tag:  `json:"a" yaml:"b"`,
 			expected: Tags{
-				{Key: "json", Value: "a"},
-				{Key: "yaml", Value: "b"},
+				{Key: "json", Value: "a", Spans: parser.Spans{Key: parser.Span{Start: 0, End: 4}, QuotedValue: parser.Span{Start: 5, End: 8}, Value: parser.Span{Start: 6, End: 7}}},
+				{Key: "yaml", Value: "b", Spans: parser.Spans{Key: parser.Span{Start: 9, End: 13}, QuotedValue: parser.Span{Start: 14, End: 17}, Value: parser.Span{Start: 15, End: 16}}},
 			},
 		},
 		{
 			desc: "identical keys",
 			tag:  `json:"a" json:"b"`,
 			expected: Tags{
-				{Key: "json", Value: "a"},
+				{Key: "json", Value: "a", Spans: parser.Spans{Key: parser.Span{Start: 0, End: 4}, QuotedValue: parser.Span{Start: 5, End: 8}, Value: parser.Span{Start: 6, End: 7}}},
 			},
 		},
 	}
@@ -78,4 +79,59 @@ func TestParse(t *testing.T) {
 func TestParse_options(t *testing.T) {
 	_, err := Parse(`a:"1" a:"2"`, WithDuplicateKeysMode(DuplicateKeysDeny))
 	require.EqualError(t, err, `duplicate key "a"`)
+	require.ErrorIs(t, err, parser.ErrDuplicateKey)
+
+	_, err = Parse(`a:"1"  b:"2"`, WithTagOptions(parser.WithStrict()))
+	require.ErrorIs(t, err, parser.ErrMultipleSpaces)
+}
+
+func TestNewPool(t *testing.T) {
+	pool := NewPool(WithDuplicateKeysMode(DuplicateKeysDeny))
+
+	err := pool.Parse(`a:"1" b:"2"`, func(data Tags) error {
+		assert.Len(t, data, 2)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	// The keys of a reused filler are cleared: the same key is not a duplicate.
+	err = pool.Parse(`a:"3"`, func(data Tags) error {
+		require.Len(t, data, 1)
+		assert.Equal(t, "3", data[0].Value)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	err = pool.Parse(``, func(data Tags) error {
+		assert.Empty(t, data)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	err = pool.Parse(`a:"1" a:"2"`, func(_ Tags) error { return nil })
+	require.ErrorIs(t, err, parser.ErrDuplicateKey)
+}
+
+func TestParse_duplicateKeys(t *testing.T) {
+	// Ignored by default.
+	tags, err := Parse(`a:"1" b:"2" a:"3,4"`)
+	require.NoError(t, err)
+
+	assert.Equal(t, `a:"1" b:"2"`, tags.String())
+
+	tags, err = Parse(`a:"1" b:"2" a:"3,4"`, WithDuplicateKeysMode(DuplicateKeysMerge))
+	require.NoError(t, err)
+
+	assert.Equal(t, `a:"1,3,4" b:"2"`, tags.String())
+}
+
+func TestParse_withKeys(t *testing.T) {
+	// The skipped pairs are not duplicate keys.
+	tags, err := Parse(`c:"0" a:"1" a:"2" b:"3"`, WithDuplicateKeysMode(DuplicateKeysDeny), WithoutKeys("a"))
+	require.NoError(t, err)
+
+	assert.Equal(t, `c:"0" b:"3"`, tags.String())
 }
@@ -2,33 +2,74 @@ package raw
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+
+	"github.com/ldez/structtags/parser"
 )
 
-type DuplicateKeysMode int
+// DuplicateKeysMode defines how the duplicate keys are handled (see [parser.DuplicateKeysMode]).
+type DuplicateKeysMode = parser.DuplicateKeysMode
 
 const (
 	// DuplicateKeysIgnore skips silently duplicate keys.
-	DuplicateKeysIgnore DuplicateKeysMode = iota
+	DuplicateKeysIgnore = parser.DuplicateKeysIgnore
 
 	// DuplicateKeysDeny throws an error when duplicate keys are found.
-	DuplicateKeysDeny
+	DuplicateKeysDeny = parser.DuplicateKeysDeny
 
 	// DuplicateKeysAllow NOT RECOMMENDED: this does not follow the struct tag conventions.
-	DuplicateKeysAllow
+	DuplicateKeysAllow = parser.DuplicateKeysAllow
+
+	// DuplicateKeysLastWins replaces the value of the first key by the value of the duplicate key.
+	DuplicateKeysLastWins = parser.DuplicateKeysLastWins
+
+	// DuplicateKeysMerge appends the value of the duplicate key to the value of the first key, separated by a comma.
+	DuplicateKeysMerge = parser.DuplicateKeysMerge
 )
 
 // config for the parser.
 type config struct {
-	// DuplicateKeysMode allows duplicate keys.
-	DuplicateKeysMode DuplicateKeysMode
+	// DuplicateKeys defines how the duplicate keys are handled.
+	DuplicateKeys parser.DuplicateKeysPolicy
+
+	// TagOptions are the options of the struct tag parser.
+	TagOptions []parser.TagOption
 }
 
 type Option func(*config)
 
 func WithDuplicateKeysMode(mode DuplicateKeysMode) Option {
 	return func(opts *config) {
-		opts.DuplicateKeysMode = mode
+		opts.DuplicateKeys = mode
+	}
+}
+
+// WithDuplicateKeysPolicy sets how the duplicate keys are handled, key by key (see [parser.DuplicateKeysPerKey]).
+func WithDuplicateKeysPolicy(policy parser.DuplicateKeysPolicy) Option {
+	return func(opts *config) {
+		opts.DuplicateKeys = policy
+	}
+}
+
+// WithTagOptions sets the options of the struct tag parser (see [parser.Tag]).
+func WithTagOptions(options ...parser.TagOption) Option {
+	return func(opts *config) {
+		opts.TagOptions = append(opts.TagOptions, options...)
+	}
+}
+
+// WithKeys only appends the pairs with the given keys to the [Tags] (see [parser.WithKeys]).
+func WithKeys(keys ...string) Option {
+	return func(opts *config) {
+		opts.TagOptions = append(opts.TagOptions, parser.WithKeys(keys...))
+	}
+}
+
+// WithoutKeys doesn't append the pairs with the given keys to the [Tags] (see [parser.WithoutKeys]).
+func WithoutKeys(keys ...string) Option {
+	return func(opts *config) {
+		opts.TagOptions = append(opts.TagOptions, parser.WithoutKeys(keys...))
 	}
 }
 
@@ -47,4 +88,12 @@ func (t Tags) String() string {
 type Tag struct {
 	Key   string
 	Value string
+
+	// Spans are the location of the key and the value inside the parsed struct tag.
+	Spans parser.Spans
+}
+
+// Clone returns a copy of the [Tags].
+func (t Tags) Clone() Tags {
+	return slices.Clone(t)
 }
@@ -47,3 +47,13 @@ func TestTags_String(t *testing.T) {
 		})
 	}
 }
+
+func TestTags_Clone(t *testing.T) {
+	tags := Tags{{Key: "a", Value: "b"}}
+
+	c := tags.Clone()
+	c[0].Value = "c"
+
+	assert.Equal(t, Tags{{Key: "a", Value: "b"}}, tags)
+	assert.Equal(t, Tags{{Key: "a", Value: "c"}}, c)
+}
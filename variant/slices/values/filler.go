@@ -1,25 +1,24 @@
 package values
 
-import (
-	"fmt"
-
-	"github.com/ldez/structtags/parser"
-)
+import "github.com/ldez/structtags/parser"
 
 type Filler struct {
 	data Tags
 
-	keys map[string]struct{}
+	// keys are the indexes of the first entries of the keys.
+	keys map[string]int
 
-	escapeComma       bool
-	duplicateKeysMode DuplicateKeysMode
+	escapeComma   bool
+	duplicateKeys parser.DuplicateKeysPolicy
+	valueOptions  []parser.ValueOption
 }
 
-func NewFiller(escapeComma bool, duplicateKeysMode DuplicateKeysMode) *Filler {
+func NewFiller(escapeComma bool, duplicateKeys parser.DuplicateKeysPolicy, valueOptions ...parser.ValueOption) *Filler {
 	return &Filler{
-		keys:              make(map[string]struct{}),
-		escapeComma:       escapeComma,
-		duplicateKeysMode: duplicateKeysMode,
+		keys:          make(map[string]int),
+		escapeComma:   escapeComma,
+		duplicateKeys: duplicateKeys,
+		valueOptions:  valueOptions,
 	}
 }
 
@@ -27,31 +26,57 @@ func (f *Filler) Data() Tags {
 	return f.data
 }
 
+func (f *Filler) Reset() {
+	clear(f.keys)
+
+	f.data = f.data[:0]
+}
+
 func (f *Filler) Fill(key, value string) error {
-	if _, ok := f.keys[key]; ok {
-		switch f.duplicateKeysMode {
+	return f.FillSpans(key, value, parser.Spans{})
+}
+
+func (f *Filler) FillSpans(key, value string, spans parser.Spans) error {
+	i, duplicate := f.keys[key]
+
+	// mode is only used for a duplicate key.
+	mode := DuplicateKeysAllow
+
+	if duplicate {
+		mode = parser.DuplicateKeyMode(f.duplicateKeys, key, DuplicateKeysIgnore)
+
+		switch mode {
 		case DuplicateKeysIgnore:
 			return nil
 
 		case DuplicateKeysDeny:
-			return fmt.Errorf("duplicate key %q", key)
-
-		case DuplicateKeysAllow:
-			// Do nothing.
+			return parser.NewDuplicateKeyError(key)
 		}
 	}
 
-	f.keys[key] = struct{}{}
-
-	values, err := parser.Value(value, f.escapeComma)
+	values, err := parser.KeyValue(key, value, f.escapeComma, f.valueOptions...)
 	if err != nil {
 		return err
 	}
 
-	f.data = append(f.data, Tag{
-		Key:    key,
-		Values: values,
-	})
+	switch mode {
+	case DuplicateKeysLastWins:
+		f.data[i] = Tag{Key: key, Values: values, Spans: spans}
+
+	case DuplicateKeysMerge:
+		f.data[i].Values = append(f.data[i].Values, values...)
+
+	default:
+		if !duplicate {
+			f.keys[key] = len(f.data)
+		}
+
+		f.data = append(f.data, Tag{
+			Key:    key,
+			Values: values,
+			Spans:  spans,
+		})
+	}
 
 	return nil
 }
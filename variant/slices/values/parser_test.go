@@ -3,6 +3,7 @@ package values
 import (
 	"testing"
 
+	"github.com/ldez/structtags/parser"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -21,49 +22,49 @@ func TestParse(t *testing.T) {
 		{
 			desc:     "empty value",
 			tag:      `json:""`,
-			expected: Tags{{Key: "json", Values: []string{""}}},
+			expected: Tags{{Key: "json", Values: []string{""}, Spans: parser.Spans{Key: parser.Span{Start: 0, End: 4}, QuotedValue: parser.Span{Start: 5, End: 7}, Value: parser.Span{Start: 6, End: 6}}}},
 		},
 		{
 			desc:     "simple value",
 			tag:      `json:"a"`,
-			expected: Tags{{Key: "json", Values: []string{"a"}}},
+			expected: Tags{{Key: "json", Values: []string{"a"}, Spans: parser.Spans{Key: parser.Span{Start: 0, End: 4}, QuotedValue: parser.Span{Start: 5, End: 8}, Value: parser.Span{Start: 6, End: 7}}}},
 		},
 		{
 			desc:     "multiple values",
 			tag:      `json:"a,b,c"`,
-			expected: Tags{{Key: "json", Values: []string{"a", "b", "c"}}},
+			expected: Tags{{Key: "json", Values: []string{"a", "b", "c"}, Spans: parser.Spans{Key: parser.Span{Start: 0, End: 4}, QuotedValue: parser.Span{Start: 5, End: 12}, Value: parser.Span{Start: 6, End: 11}}}},
 		},
 		{
 			desc:     "quoted value",
 			tag:      `json:"a:\"b\""`,
-			expected: Tags{{Key: "json", Values: []string{"a:\"b\""}}},
+			expected: Tags{{Key: "json", Values: []string{"a:\"b\""}, Spans: parser.Spans{Key: parser.Span{Start: 0, End: 4}, QuotedValue: parser.Span{Start: 5, End: 14}, Value: parser.Span{Start: 6, End: 13}}}},
 		},
 		{
 			desc:     "escaped coma",
 			tag:      `json:"b\\,c\\,d,e"`,
-			expected: Tags{{Key: "json", Values: []string{"b\\,c\\,d", "e"}}},
+			expected: Tags{{Key: "json", Values: []string{"b\\,c\\,d", "e"}, Spans: parser.Spans{Key: parser.Span{Start: 0, End: 4}, QuotedValue: parser.Span{Start: 5, End: 18}, Value: parser.Span{Start: 6, End: 17}}}},
 		},
 		{
 			desc: "multiple empty tag",
 			tag:  `json:"" yaml:""`,
 			expected: Tags{
-				{Key: "json", Values: []string{""}},
-				{Key: "yaml", Values: []string{""}},
+				{Key: "json", Values: []string{""}, Spans: parser.Spans{Key: parser.Span{Start: 0, End: 4}, QuotedValue: parser.Span{Start: 5, End: 7}, Value: parser.Span{Start: 6, End: 6}}},
+				{Key: "yaml", Values: []string{""}, Spans: parser.Spans{Key: parser.Span{Start: 8, End: 12}, QuotedValue: parser.Span{Start: 13, End: 15}, Value: parser.Span{Start: 14, End: 14}}},
 			},
 		},
 		{
 			desc: "multiple tag",
 			tag:  `json:"a" yaml:"b"`,
 			expected: Tags{
-				{Key: "json", Values: []string{"a"}},
-				{Key: "yaml", Values: []string{"b"}},
+				{Key: "json", Values: []string{"a"}, Spans: parser.Spans{Key: parser.Span{Start: 0, End: 4}, QuotedValue: parser.Span{Start: 5, End: 8}, Value: parser.Span{Start: 6, End: 7}}},
+				{Key: "yaml", Values: []string{"b"}, Spans: parser.Spans{Key: parser.Span{Start: 9, End: 13}, QuotedValue: parser.Span{Start: 14, End: 17}, Value: parser.Span{Start: 15, End: 16}}},
 			},
 		},
 		{
 			desc: "identical keys",
 			tag:  `json:"a" json:"b"`,
 			expected: Tags{
-				{Key: "json", Values: []string{"a"}},
+				{Key: "json", Values: []string{"a"}, Spans: parser.Spans{Key: parser.Span{Start: 0, End: 4}, QuotedValue: parser.Span{Start: 5, End: 8}, Value: parser.Span{Start: 6, End: 7}}},
 			},
 		},
 	}
@@ -83,4 +84,111 @@ func TestParse(t *testing.T) {
 func TestParse_options(t *testing.T) {
 	_, err := Parse(`a:"1" a:"2"`, WithDuplicateKeysMode(DuplicateKeysDeny))
 	require.EqualError(t, err, `duplicate key "a"`)
+	require.ErrorIs(t, err, parser.ErrDuplicateKey)
+
+	_, err = Parse(`a:"1"  b:"2"`, WithTagOptions(parser.WithStrict()))
+	require.ErrorIs(t, err, parser.ErrMultipleSpaces)
+
+	_, err = Parse(`a:"1,2"`, WithValueOptions(parser.WithMaxValues(1)))
+	require.ErrorIs(t, err, parser.ErrTooManyValues)
+}
+
+func TestParse_unescapeComma(t *testing.T) {
+	tags, err := Parse(`a:"e,f\\,g,h\\\\"`, WithUnescapeComma())
+	require.NoError(t, err)
+
+	assert.Equal(t, Tags{{Key: "a", Values: []string{"e", "f,g", `h\`}, Spans: parser.Spans{Key: parser.Span{Start: 0, End: 1}, QuotedValue: parser.Span{Start: 2, End: 17}, Value: parser.Span{Start: 3, End: 16}}}}, tags)
+}
+
+func TestParse_separator(t *testing.T) {
+	tags, err := Parse(`gorm:"column:name;unique"`, WithSeparator(';'))
+	require.NoError(t, err)
+
+	assert.Equal(t, Tags{{Key: "gorm", Values: []string{"column:name", "unique"}, Spans: parser.Spans{Key: parser.Span{Start: 0, End: 4}, QuotedValue: parser.Span{Start: 5, End: 25}, Value: parser.Span{Start: 6, End: 24}}}}, tags)
+
+	tags, err = Parse(`validate:"a|b^|c"`, WithSeparator('|'), WithEscapeChar('^'), WithValueOptions(parser.WithUnescape()))
+	require.NoError(t, err)
+
+	assert.Equal(t, Tags{{Key: "validate", Values: []string{"a", "b|c"}, Spans: parser.Spans{Key: parser.Span{Start: 0, End: 8}, QuotedValue: parser.Span{Start: 9, End: 17}, Value: parser.Span{Start: 10, End: 16}}}}, tags)
+}
+
+func TestParse_singleQuotes(t *testing.T) {
+	tags, err := Parse(`json:"'a,b',omitempty"`, WithSingleQuotes())
+	require.NoError(t, err)
+
+	assert.Equal(t, Tags{{Key: "json", Values: []string{"a,b", "omitempty"}, Spans: parser.Spans{Key: parser.Span{Start: 0, End: 4}, QuotedValue: parser.Span{Start: 5, End: 22}, Value: parser.Span{Start: 6, End: 21}}}}, tags)
+}
+
+func TestNewPool(t *testing.T) {
+	pool := NewPool(WithDuplicateKeysMode(DuplicateKeysDeny), WithEscapeCommaKeys("a"))
+
+	err := pool.Parse(`a:"1\\,2,3" b:"4\\,5"`, func(data Tags) error {
+		require.Len(t, data, 2)
+		assert.Equal(t, []string{`1\,2`, "3"}, data[0].Values)
+		assert.Equal(t, []string{`4\`, "5"}, data[1].Values)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	// The keys of a reused filler are cleared: the same key is not a duplicate.
+	err = pool.Parse(`a:"6"`, func(data Tags) error {
+		require.Len(t, data, 1)
+		assert.Equal(t, []string{"6"}, data[0].Values)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	err = pool.Parse(``, func(data Tags) error {
+		assert.Empty(t, data)
+
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestParse_duplicateKeys(t *testing.T) {
+	// Ignored by default.
+	tags, err := Parse(`a:"1" b:"2" a:"3,4"`)
+	require.NoError(t, err)
+
+	assert.Equal(t, `a:"1" b:"2"`, tags.String())
+
+	tags, err = Parse(`a:"1" b:"2" a:"3,4"`, WithDuplicateKeysMode(DuplicateKeysMerge))
+	require.NoError(t, err)
+
+	require.Len(t, tags, 2)
+	assert.Equal(t, []string{"1", "3", "4"}, tags[0].Values)
+}
+
+func TestParse_keys(t *testing.T) {
+	tags, err := Parse(`default:"a\\,b,c" json:"a\\,b,c"`,
+		WithEscapeCommaKeys("default", "env-default"),
+		WithValueOptions(parser.WithUnescape()),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, [][]string{{"a,b", "c"}, {`a\`, "b", "c"}}, [][]string{tags[0].Values, tags[1].Values})
+
+	policy := WithDuplicateKeysPolicy(parser.DuplicateKeysPerKey{
+		Default: DuplicateKeysIgnore,
+		Keys:    map[string]DuplicateKeysMode{"json": DuplicateKeysDeny},
+	})
+
+	_, err = Parse(`default:"a" default:"b"`, policy)
+	require.NoError(t, err)
+
+	_, err = Parse(`json:"a" json:"b"`, policy)
+	require.ErrorIs(t, err, parser.ErrDuplicateKey)
+}
+
+func TestParse_withKeys(t *testing.T) {
+	// The values of the skipped pairs are not parsed.
+	tags, err := Parse(`a:"1\\,2" b:"'3" c:"4\\,5"`, WithSingleQuotes(), WithEscapeCommaKeys("a"), WithoutKeys("b"))
+	require.NoError(t, err)
+
+	require.Len(t, tags, 2)
+	assert.Equal(t, []string{`1\,2`}, tags[0].Values)
+	assert.Equal(t, []string{`4\`, "5"}, tags[1].Values)
 }
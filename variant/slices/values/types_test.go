@@ -47,3 +47,15 @@ func TestTags_String(t *testing.T) {
 		})
 	}
 }
+
+func TestTags_Clone(t *testing.T) {
+	tags := Tags{{Key: "a", Values: []string{"b", "c"}}}
+
+	c := tags.Clone()
+	c[0].Values[0] = "d"
+
+	assert.Equal(t, Tags{{Key: "a", Values: []string{"b", "c"}}}, tags)
+	assert.Equal(t, Tags{{Key: "a", Values: []string{"d", "c"}}}, c)
+
+	assert.Nil(t, Tags(nil).Clone())
+}
@@ -0,0 +1,109 @@
+package structured
+
+import (
+	"strconv"
+	"strings"
+)
+
+// whitespace is the set of the characters skipped between pairs (see [parser.WhitespaceLenient]).
+const whitespace = " \t\n\v\f\r"
+
+// node is the original text of an [Entry].
+type node struct {
+	// leading is the whitespace before the key.
+	leading string
+
+	// index is the position of the entry in the parsed struct tag.
+	index int
+
+	// key is the original key.
+	key string
+
+	// value is the original unquoted value.
+	value string
+
+	// quoted is the original quoted value, with the original escape sequences.
+	quoted string
+}
+
+// attachSource keeps the original text of the entries.
+func (t *Tag) attachSource(tag string) {
+	for i, entry := range t.entries {
+		spans := entry.spans
+
+		start := len(strings.TrimRight(tag[:spans.Key.Start], whitespace))
+
+		quoted := tag[spans.QuotedValue.Start:spans.QuotedValue.End]
+
+		// The value can differ from the original value (e.g., DuplicateKeysMerge).
+		value := entry.RawValue
+		if value != tag[spans.Value.Start:spans.Value.End] {
+			if unquoted, err := strconv.Unquote(quoted); err == nil {
+				value = unquoted
+			}
+		}
+
+		entry.node = &node{
+			leading: tag[start:spans.Key.Start],
+			index:   i,
+			key:     entry.Key,
+			value:   value,
+			quoted:  quoted,
+		}
+	}
+
+	t.trailing = tag[len(strings.TrimRight(tag, whitespace)):]
+}
+
+// Render returns the struct tag (`key:"value"` pairs).
+//
+// When the [Tag] is parsed with [WithCST],
+// the unchanged entries and the whitespace are rendered byte-for-byte,
+// and only the modified or new entries are re-rendered.
+// The pairs that are not in the [Tag] (e.g., duplicate keys with [DuplicateKeysIgnore], [WithKeys], [WithoutKeys]) are not rendered.
+func (t *Tag) Render() string {
+	var b strings.Builder
+
+	// prev is the original position of the previous rendered entry:
+	// -1 before the first entry, and -2 after a new entry.
+	prev := -1
+
+	for _, entry := range t.entries {
+		if entry == nil {
+			continue
+		}
+
+		entry.render(&b, prev)
+
+		prev = -2
+		if entry.node != nil {
+			prev = entry.node.index
+		}
+	}
+
+	b.WriteString(t.trailing)
+
+	return b.String()
+}
+
+func (e *Entry) render(b *strings.Builder, prev int) {
+	// The original leading whitespace (even empty) is kept only if the entry still follows its original previous entry.
+	switch {
+	case e.node != nil && e.node.index == prev+1:
+		b.WriteString(e.node.leading)
+
+	case prev != -1:
+		b.WriteString(" ")
+	}
+
+	b.WriteString(e.Key)
+	b.WriteString(":")
+
+	if e.node != nil && e.node.key == e.Key && e.node.value == e.RawValue {
+		b.WriteString(e.node.quoted)
+
+		return
+	}
+
+	b.WriteString(strconv.Quote(e.RawValue))
+}
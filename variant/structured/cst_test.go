@@ -0,0 +1,142 @@
+package structured
+
+import (
+	"testing"
+
+	"github.com/ldez/structtags/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTag_Render(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		tag      string
+		options  []Option
+		update   func(t *testing.T, tag *Tag)
+		expected string
+	}{
+		{
+			desc:     "canonical",
+			tag:      ` json:"a"   yaml:"\x41"  `,
+			expected: `json:"a" yaml:"A"`,
+		},
+		{
+			desc:     "CST: unchanged",
+			tag:      ` json:"a"   yaml:"\x41"  `,
+			options:  []Option{WithCST()},
+			expected: ` json:"a"   yaml:"\x41"  `,
+		},
+		{
+			desc:     "CST: only whitespace",
+			tag:      `   `,
+			options:  []Option{WithCST()},
+			expected: `   `,
+		},
+		{
+			desc:     "CST: no space between pairs",
+			tag:      `json:"a"yaml:"b"`,
+			options:  []Option{WithCST()},
+			expected: `json:"a"yaml:"b"`,
+		},
+		{
+			desc:     "CST: comma between pairs",
+			tag:      `json:"a",yaml:"b"`,
+			options:  []Option{WithCST()},
+			expected: `json:"a",yaml:"b"`,
+		},
+		{
+			desc:    "CST: moved entry without space",
+			tag:     `yaml:"b"json:"a"`,
+			options: []Option{WithCST()},
+			update: func(t *testing.T, tag *Tag) {
+				t.Helper()
+
+				tag.Sort()
+			},
+			expected: `json:"a" yaml:"b"`,
+		},
+		{
+			desc:     "CST: lenient whitespace",
+			tag:      "\tjson:\"a\"\t \nyaml:\"b\"\r\n",
+			options:  []Option{WithCST(), WithTagOptions(parser.WithWhitespace(parser.WhitespaceLenient))},
+			expected: "\tjson:\"a\"\t \nyaml:\"b\"\r\n",
+		},
+		{
+			desc:     "CST: ignored duplicate key",
+			tag:      `json:"a"  json:"b"   yaml:"c" `,
+			options:  []Option{WithCST()},
+			expected: `json:"a"   yaml:"c" `,
+		},
+		{
+			desc:    "CST: modified value",
+			tag:     ` json:"a"   yaml:"\x41"  xml:"é"`,
+			options: []Option{WithCST()},
+			update: func(t *testing.T, tag *Tag) {
+				t.Helper()
+
+				tag.Get("yaml").RawValue = "B"
+			},
+			expected: ` json:"a"   yaml:"B"  xml:"é"`,
+		},
+		{
+			desc:    "CST: modified key",
+			tag:     `json:"a"   yaml:"\x41"`,
+			options: []Option{WithCST()},
+			update: func(t *testing.T, tag *Tag) {
+				t.Helper()
+
+				tag.Get("yaml").Key = "toml"
+			},
+			expected: `json:"a"   toml:"A"`,
+		},
+		{
+			desc:    "CST: added entry",
+			tag:     `json:"a"   yaml:"\x41" `,
+			options: []Option{WithCST()},
+			update: func(t *testing.T, tag *Tag) {
+				t.Helper()
+
+				require.NoError(t, tag.Add(&Entry{Key: "xml", RawValue: "c"}))
+			},
+			expected: `json:"a"   yaml:"\x41" xml:"c" `,
+		},
+		{
+			desc:    "CST: deleted entry",
+			tag:     `json:"a"   yaml:"\x41" xml:"c"`,
+			options: []Option{WithCST()},
+			update: func(t *testing.T, tag *Tag) {
+				t.Helper()
+
+				tag.Delete("json")
+			},
+			expected: `yaml:"\x41" xml:"c"`,
+		},
+		{
+			desc:    "CST: sorted entries",
+			tag:     `yaml:"\x41"  json:"a"`,
+			options: []Option{WithCST()},
+			update: func(t *testing.T, tag *Tag) {
+				t.Helper()
+
+				tag.Sort()
+			},
+			expected: `json:"a" yaml:"\x41"`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			tag, err := Parse(test.tag, test.options...)
+			require.NoError(t, err)
+
+			if test.update != nil {
+				test.update(t, tag)
+			}
+
+			assert.Equal(t, test.expected, tag.Render())
+		})
+	}
+}
@@ -1,31 +1,57 @@
 package structured
 
+import "github.com/ldez/structtags/parser"
+
 // Filler fills the tag from a struct tag.
 type Filler struct {
 	data *Tag
 
-	escapeComma       bool
-	duplicateKeysMode DuplicateKeysMode
+	escapeComma   bool
+	duplicateKeys parser.DuplicateKeysPolicy
+	valueOptions  []parser.ValueOption
 }
 
 // NewFiller creates a new [Filler].
-func NewFiller(escapeComma bool, duplicateKeysMode DuplicateKeysMode) *Filler {
+func NewFiller(escapeComma bool, duplicateKeys parser.DuplicateKeysPolicy, valueOptions ...parser.ValueOption) *Filler {
 	return &Filler{
-		escapeComma:       escapeComma,
-		duplicateKeysMode: duplicateKeysMode,
+		escapeComma:   escapeComma,
+		duplicateKeys: duplicateKeys,
+		valueOptions:  valueOptions,
 	}
 }
 
 // Data returns the [Tag] filled by the struct tag content.
+// The [Tag] is empty (not nil) if the struct tag has no pairs.
 func (f *Filler) Data() *Tag {
+	if f.data == nil {
+		f.data = NewTag(f.escapeComma, f.duplicateKeys, f.valueOptions...)
+	}
+
 	return f.data
 }
 
+// Reset clears the data, and keeps the allocated memory.
+func (f *Filler) Reset() {
+	if f.data == nil {
+		return
+	}
+
+	clear(f.data.entries)
+
+	f.data.entries = f.data.entries[:0]
+	f.data.trailing = ""
+}
+
 // Fill fills the data from a struct tag.
 func (f *Filler) Fill(key, value string) error {
+	return f.FillSpans(key, value, parser.Spans{})
+}
+
+// FillSpans fills the data from a struct tag, with the location of the key and the value.
+func (f *Filler) FillSpans(key, value string, spans parser.Spans) error {
 	if f.data == nil {
-		f.data = NewTag(f.escapeComma, f.duplicateKeysMode)
+		f.data = NewTag(f.escapeComma, f.duplicateKeys, f.valueOptions...)
 	}
 
-	return f.data.Add(&Entry{Key: key, RawValue: value})
+	return f.data.Add(&Entry{Key: key, RawValue: value, spans: spans})
 }
@@ -40,8 +40,8 @@ func TestFiller_Fill(t *testing.T) {
 						escapeComma: false,
 					},
 				},
-				escapeComma:       false,
-				duplicateKeysMode: DuplicateKeysDeny,
+				escapeComma:   false,
+				duplicateKeys: DuplicateKeysDeny,
 			},
 		},
 		{
@@ -65,8 +65,8 @@ func TestFiller_Fill(t *testing.T) {
 						escapeComma: true,
 					},
 				},
-				escapeComma:       true,
-				duplicateKeysMode: DuplicateKeysDeny,
+				escapeComma:   true,
+				duplicateKeys: DuplicateKeysDeny,
 			},
 		},
 		{
@@ -90,8 +90,8 @@ func TestFiller_Fill(t *testing.T) {
 						escapeComma: false,
 					},
 				},
-				escapeComma:       false,
-				duplicateKeysMode: DuplicateKeysAllow,
+				escapeComma:   false,
+				duplicateKeys: DuplicateKeysAllow,
 			},
 		},
 		{
@@ -115,8 +115,8 @@ func TestFiller_Fill(t *testing.T) {
 						escapeComma: true,
 					},
 				},
-				escapeComma:       true,
-				duplicateKeysMode: DuplicateKeysAllow,
+				escapeComma:   true,
+				duplicateKeys: DuplicateKeysAllow,
 			},
 		},
 	}
@@ -149,8 +149,8 @@ func TestFiller_Fill_duplicate_ignore(t *testing.T) {
 		entries: []*Entry{
 			{Key: "a", RawValue: "b"},
 		},
-		escapeComma:       false,
-		duplicateKeysMode: DuplicateKeysIgnore,
+		escapeComma:   false,
+		duplicateKeys: DuplicateKeysIgnore,
 	}
 
 	assert.Equal(t, expected, filler.Data())
@@ -180,8 +180,8 @@ func TestFiller_Fill_duplicate_allow(t *testing.T) {
 			{Key: "a", RawValue: "b"},
 			{Key: "a", RawValue: "c"},
 		},
-		escapeComma:       false,
-		duplicateKeysMode: DuplicateKeysAllow,
+		escapeComma:   false,
+		duplicateKeys: DuplicateKeysAllow,
 	}
 
 	assert.Equal(t, expected, filler.Data())
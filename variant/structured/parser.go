@@ -13,9 +13,34 @@ func Parse(tag string, options ...Option) (*Tag, error) {
 		opt(&cfg)
 	}
 
-	if tag == "" {
-		return NewTag(cfg.EscapeComma, cfg.DuplicateKeysMode), nil
+	data, err := parser.Tag(tag, NewFiller(cfg.EscapeComma, cfg.DuplicateKeys, cfg.ValueOptions...), cfg.TagOptions...)
+	if data == nil {
+		if err != nil {
+			return nil, err
+		}
+
+		data = NewTag(cfg.EscapeComma, cfg.DuplicateKeys, cfg.ValueOptions...)
+	}
+
+	if cfg.CST {
+		data.attachSource(tag)
+	}
+
+	// The error is not nil only with partial data (parser.WithRecovery).
+	return data, err
+}
+
+// NewPool creates a pool of reusable [Filler] with the [Parse] options.
+// The same [*Tag] is reused by the next parsings: use [Tag.Clone] to keep it after the callback of [parser.FillerPool.Parse].
+// [WithCST] is not supported.
+func NewPool(options ...Option) *parser.FillerPool[*Tag] {
+	var cfg config
+
+	for _, opt := range options {
+		opt(&cfg)
 	}
 
-	return parser.Tag(tag, NewFiller(cfg.EscapeComma, cfg.DuplicateKeysMode))
+	return parser.NewFillerPool(func() parser.ResetFiller[*Tag] {
+		return NewFiller(cfg.EscapeComma, cfg.DuplicateKeys, cfg.ValueOptions...)
+	}, cfg.TagOptions...)
 }
@@ -4,6 +4,7 @@ import (
 	"slices"
 	"testing"
 
+	"github.com/ldez/structtags/parser"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -24,14 +25,14 @@ func TestParse(t *testing.T) {
 			desc: "empty value",
 			tag:  `json:""`,
 			expected: []*Entry{
-				{Key: "json", RawValue: ""},
+				{Key: "json", RawValue: "", spans: parser.Spans{Key: parser.Span{Start: 0, End: 4}, QuotedValue: parser.Span{Start: 5, End: 7}, Value: parser.Span{Start: 6, End: 6}}},
 			},
 		},
 		{
 			desc: "simple value",
 			tag:  `json:"a"`,
 			expected: []*Entry{
-				{Key: "json", RawValue: "a"},
+				{Key: "json", RawValue: "a", spans: parser.Spans{Key: parser.Span{Start: 0, End: 4}, QuotedValue: parser.Span{Start: 5, End: 8}, Value: parser.Span{Start: 6, End: 7}}},
 			},
 		},
 		{
@@ -39,37 +40,37 @@ func TestParse(t *testing.T) {
 			tag:  `json:"a,b,c"`,
 
 			expected: []*Entry{
-				{Key: "json", RawValue: "a,b,c"},
+				{Key: "json", RawValue: "a,b,c", spans: parser.Spans{Key: parser.Span{Start: 0, End: 4}, QuotedValue: parser.Span{Start: 5, End: 12}, Value: parser.Span{Start: 6, End: 11}}},
 			},
 		},
 		{
 			desc: "quoted value",
 			tag:  `json:"a:\"b\""`,
 			expected: []*Entry{
-				{Key: "json", RawValue: "a:\"b\""},
+				{Key: "json", RawValue: "a:\"b\"", spans: parser.Spans{Key: parser.Span{Start: 0, End: 4}, QuotedValue: parser.Span{Start: 5, End: 14}, Value: parser.Span{Start: 6, End: 13}}},
 			},
 		},
 		{
 			desc: "ignore escaped coma",
 			tag:  `json:"b\\,c\\,d,e"`,
 			expected: []*Entry{
-				{Key: "json", RawValue: "b\\,c\\,d,e"},
+				{Key: "json", RawValue: "b\\,c\\,d,e", spans: parser.Spans{Key: parser.Span{Start: 0, End: 4}, QuotedValue: parser.Span{Start: 5, End: 18}, Value: parser.Span{Start: 6, End: 17}}},
 			},
 		},
 		{
 			desc: "multiple empty tag",
 			tag:  `json:"" yaml:""`,
 			expected: []*Entry{
-				{Key: "json", RawValue: ""},
-				{Key: "yaml", RawValue: ""},
+				{Key: "json", RawValue: "", spans: parser.Spans{Key: parser.Span{Start: 0, End: 4}, QuotedValue: parser.Span{Start: 5, End: 7}, Value: parser.Span{Start: 6, End: 6}}},
+				{Key: "yaml", RawValue: "", spans: parser.Spans{Key: parser.Span{Start: 8, End: 12}, QuotedValue: parser.Span{Start: 13, End: 15}, Value: parser.Span{Start: 14, End: 14}}},
 			},
 		},
 		{
 			desc: "multiple tag",
 			tag:  `json:"a" yaml:"b"`,
 			expected: []*Entry{
-				{Key: "json", RawValue: "a"},
-				{Key: "yaml", RawValue: "b"},
+				{Key: "json", RawValue: "a", spans: parser.Spans{Key: parser.Span{Start: 0, End: 4}, QuotedValue: parser.Span{Start: 5, End: 8}, Value: parser.Span{Start: 6, End: 7}}},
+				{Key: "yaml", RawValue: "b", spans: parser.Spans{Key: parser.Span{Start: 9, End: 13}, QuotedValue: parser.Span{Start: 14, End: 17}, Value: parser.Span{Start: 15, End: 16}}},
 			},
 		},
 		{
@@ -77,8 +78,8 @@ func TestParse(t *testing.T) {
 			tag:     `json:"a" json:"b"`,
 			options: []Option{WithDuplicateKeysMode(DuplicateKeysAllow)},
 			expected: []*Entry{
-				{Key: "json", RawValue: "a"},
-				{Key: "json", RawValue: "b"},
+				{Key: "json", RawValue: "a", spans: parser.Spans{Key: parser.Span{Start: 0, End: 4}, QuotedValue: parser.Span{Start: 5, End: 8}, Value: parser.Span{Start: 6, End: 7}}},
+				{Key: "json", RawValue: "b", spans: parser.Spans{Key: parser.Span{Start: 9, End: 13}, QuotedValue: parser.Span{Start: 14, End: 17}, Value: parser.Span{Start: 15, End: 16}}},
 			},
 		},
 	}
@@ -110,8 +111,186 @@ func TestParse_options(t *testing.T) {
 			Key:         "a",
 			RawValue:    "1\\,2",
 			escapeComma: true,
+			spans:       parser.Spans{Key: parser.Span{Start: 0, End: 1}, QuotedValue: parser.Span{Start: 2, End: 9}, Value: parser.Span{Start: 3, End: 8}},
 		},
 	}
 
 	assert.Equal(t, expected, slices.Collect(tags.Seq()))
 }
+
+func TestParse_options_value(t *testing.T) {
+	tags, err := Parse(`a:"1,2"`, WithValueOptions(parser.WithMaxValues(1)))
+	require.NoError(t, err)
+
+	entry := tags.Get("a")
+	require.NotNil(t, entry)
+
+	_, err = entry.Values()
+	require.ErrorIs(t, err, parser.ErrTooManyValues)
+
+	// The options also apply to the added entries.
+	err = tags.Add(&Entry{Key: "b", RawValue: "3,4"})
+	require.NoError(t, err)
+
+	_, err = tags.Get("b").Values()
+	require.ErrorIs(t, err, parser.ErrTooManyValues)
+}
+
+func TestParse_options_tag(t *testing.T) {
+	_, err := Parse(`a:"1"  b:"2"`, WithTagOptions(parser.WithStrict()))
+	require.ErrorIs(t, err, parser.ErrMultipleSpaces)
+
+	tags, err := Parse(`a:"1" b c:"3"`, WithTagOptions(parser.WithRecovery()))
+	require.ErrorIs(t, err, parser.ErrMissingColon)
+
+	expected := []*Entry{
+		{Key: "a", RawValue: "1", spans: parser.Spans{Key: parser.Span{Start: 0, End: 1}, QuotedValue: parser.Span{Start: 2, End: 5}, Value: parser.Span{Start: 3, End: 4}}},
+		{Key: "c", RawValue: "3", spans: parser.Spans{Key: parser.Span{Start: 8, End: 9}, QuotedValue: parser.Span{Start: 10, End: 13}, Value: parser.Span{Start: 11, End: 12}}},
+	}
+
+	assert.Equal(t, expected, slices.Collect(tags.Seq()))
+}
+
+func TestParse_unescapeComma(t *testing.T) {
+	tags, err := Parse(`a:"e,f\\,g,h\\\\"`, WithUnescapeComma())
+	require.NoError(t, err)
+
+	entry := tags.Get("a")
+	require.NotNil(t, entry)
+
+	values, err := entry.Values()
+	require.NoError(t, err)
+
+	assert.Equal(t, TagValues{"e", "f,g", `h\`}, values)
+
+	entry.RawValue = parser.JoinEscaped(append(values, "i,j"))
+
+	assert.Equal(t, `a:"e,f\\,g,h\\\\,i\\,j"`, tags.Render())
+}
+
+func TestParse_separator(t *testing.T) {
+	tags, err := Parse(`gorm:"column:name;unique"`, WithSeparator(';'))
+	require.NoError(t, err)
+
+	entry := tags.Get("gorm")
+	require.NotNil(t, entry)
+
+	values, err := entry.Values()
+	require.NoError(t, err)
+
+	assert.Equal(t, TagValues{"column:name", "unique"}, values)
+}
+
+func TestParse_singleQuotes(t *testing.T) {
+	tags, err := Parse(`json:"'a,b',omitempty"`, WithSingleQuotes())
+	require.NoError(t, err)
+
+	entry := tags.Get("json")
+	require.NotNil(t, entry)
+
+	values, err := entry.Values()
+	require.NoError(t, err)
+
+	assert.Equal(t, TagValues{"a,b", "omitempty"}, values)
+}
+
+func TestNewPool(t *testing.T) {
+	pool := NewPool(WithEscapeCommaKeys("a"))
+
+	err := pool.Parse(`a:"1\\,2,3" b:"4\\,5"`, func(data *Tag) error {
+		values, err := data.Get("a").Values()
+		require.NoError(t, err)
+
+		assert.Equal(t, TagValues{`1\,2`, "3"}, values)
+
+		values, err = data.Get("b").Values()
+		require.NoError(t, err)
+
+		assert.Equal(t, TagValues{`4\`, "5"}, values)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	// Like Parse, an empty struct tag is an empty Tag (not nil).
+	err = pool.Parse(``, func(data *Tag) error {
+		require.NotNil(t, data)
+		assert.True(t, data.IsEmpty())
+		assert.Empty(t, data.Render())
+
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestParse_duplicateKeys(t *testing.T) {
+	// Ignored by default.
+	tags, err := Parse(`a:"1" b:"2" a:"3,4"`)
+	require.NoError(t, err)
+
+	assert.Equal(t, `a:"1" b:"2"`, tags.Render())
+
+	tags, err = Parse(`a:"1" b:"2" a:"3,4"`, WithDuplicateKeysMode(DuplicateKeysMerge))
+	require.NoError(t, err)
+
+	assert.Equal(t, `a:"1,3,4" b:"2"`, tags.Render())
+}
+
+func TestParse_duplicateKeys_mergeKeyOptions(t *testing.T) {
+	tags, err := Parse(`gorm:"column:id" gorm:"type:int"`,
+		WithDuplicateKeysMode(DuplicateKeysMerge),
+		WithValueOptions(parser.ForKeys([]string{"gorm"}, parser.WithSeparator(';'))),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, `gorm:"column:id;type:int"`, tags.Render())
+
+	values, err := tags.Get("gorm").Values()
+	require.NoError(t, err)
+
+	assert.Equal(t, TagValues{"column:id", "type:int"}, values)
+}
+
+func TestParse_duplicateKeys_cst(t *testing.T) {
+	tags, err := Parse(`a:"1"  b:"2" a:"3"`, WithCST(), WithDuplicateKeysMode(DuplicateKeysMerge))
+	require.NoError(t, err)
+
+	assert.Equal(t, `a:"1,3"  b:"2"`, tags.Render())
+}
+
+func TestParse_keys(t *testing.T) {
+	tags, err := Parse(`default:"a\\,b,c" json:"a\\,b,c"`,
+		WithEscapeCommaKeys("default", "env-default"),
+		WithValueOptions(parser.WithUnescape()),
+	)
+	require.NoError(t, err)
+
+	values, err := tags.Get("default").Values()
+	require.NoError(t, err)
+
+	assert.Equal(t, TagValues{"a,b", "c"}, values)
+
+	values, err = tags.Get("json").Values()
+	require.NoError(t, err)
+
+	assert.Equal(t, TagValues{`a\`, "b", "c"}, values)
+
+	policy := WithDuplicateKeysPolicy(parser.DuplicateKeysPerKey{
+		Default: DuplicateKeysIgnore,
+		Keys:    map[string]DuplicateKeysMode{"json": DuplicateKeysDeny},
+	})
+
+	_, err = Parse(`default:"a" default:"b"`, policy)
+	require.NoError(t, err)
+
+	_, err = Parse(`json:"a" json:"b"`, policy)
+	require.ErrorIs(t, err, parser.ErrDuplicateKey)
+}
+
+func TestParse_withKeys(t *testing.T) {
+	// The skipped pairs are neither parsed nor merged.
+	tags, err := Parse(`a:"1" b:"'2" a:"3"`, WithSingleQuotes(), WithDuplicateKeysMode(DuplicateKeysMerge), WithKeys("a"))
+	require.NoError(t, err)
+
+	assert.Equal(t, `a:"1,3"`, tags.Render())
+}
@@ -9,17 +9,24 @@ import (
 	"github.com/ldez/structtags/parser"
 )
 
-type DuplicateKeysMode int
+// DuplicateKeysMode defines how the duplicate keys are handled (see [parser.DuplicateKeysMode]).
+type DuplicateKeysMode = parser.DuplicateKeysMode
 
 const (
 	// DuplicateKeysIgnore skips silently duplicate keys.
-	DuplicateKeysIgnore DuplicateKeysMode = iota
+	DuplicateKeysIgnore = parser.DuplicateKeysIgnore
 
 	// DuplicateKeysDeny throws an error when duplicate keys are found.
-	DuplicateKeysDeny
+	DuplicateKeysDeny = parser.DuplicateKeysDeny
 
 	// DuplicateKeysAllow NOT RECOMMENDED: this does not follow the struct tag conventions.
-	DuplicateKeysAllow
+	DuplicateKeysAllow = parser.DuplicateKeysAllow
+
+	// DuplicateKeysLastWins replaces the value of the first key by the value of the duplicate key.
+	DuplicateKeysLastWins = parser.DuplicateKeysLastWins
+
+	// DuplicateKeysMerge appends the value of the duplicate key to the value of the first key, separated by the separator of the values.
+	DuplicateKeysMerge = parser.DuplicateKeysMerge
 )
 
 // config for the parser.
@@ -27,8 +34,17 @@ type config struct {
 	// EscapeComma is used to escape the comma character within the value.
 	EscapeComma bool
 
-	// DuplicateKeysMode allows duplicate keys.
-	DuplicateKeysMode DuplicateKeysMode
+	// DuplicateKeys defines how the duplicate keys are handled.
+	DuplicateKeys parser.DuplicateKeysPolicy
+
+	// TagOptions are the options of the struct tag parser.
+	TagOptions []parser.TagOption
+
+	// ValueOptions are the options of the value parser.
+	ValueOptions []parser.ValueOption
+
+	// CST keeps the original text of the struct tag (see [Tag.Render]).
+	CST bool
 }
 
 type Option func(*config)
@@ -39,9 +55,92 @@ func WithEscapeComma() Option {
 	}
 }
 
+// WithUnescapeComma escapes the comma (like [WithEscapeComma]),
+// and removes the escape backslashes from the values (see [parser.WithUnescape] and [parser.JoinEscaped]).
+func WithUnescapeComma() Option {
+	return func(opts *config) {
+		opts.EscapeComma = true
+		opts.ValueOptions = append(opts.ValueOptions, parser.WithUnescape())
+	}
+}
+
+// WithEscapeCommaKeys escapes the comma only in the values of the given keys (see [parser.ForKeys]).
+// For example, `default` and `env-default` honour the escapes, and `json` doesn't.
+func WithEscapeCommaKeys(keys ...string) Option {
+	return func(opts *config) {
+		opts.ValueOptions = append(opts.ValueOptions, parser.ForKeys(keys, parser.WithEscapeComma()))
+	}
+}
+
+// WithSeparator sets the separator of the values (see [parser.WithSeparator]).
+func WithSeparator(sep byte) Option {
+	return func(opts *config) {
+		opts.ValueOptions = append(opts.ValueOptions, parser.WithSeparator(sep))
+	}
+}
+
+// WithEscapeChar sets the escape character of the separator, and enables the escaping (see [parser.WithEscapeChar]).
+func WithEscapeChar(c byte) Option {
+	return func(opts *config) {
+		opts.EscapeComma = true
+		opts.ValueOptions = append(opts.ValueOptions, parser.WithEscapeChar(c))
+	}
+}
+
+// WithSingleQuotes handles a single-quoted value as one value (see [parser.WithSingleQuotes]).
+func WithSingleQuotes() Option {
+	return func(opts *config) {
+		opts.ValueOptions = append(opts.ValueOptions, parser.WithSingleQuotes())
+	}
+}
+
 func WithDuplicateKeysMode(mode DuplicateKeysMode) Option {
 	return func(opts *config) {
-		opts.DuplicateKeysMode = mode
+		opts.DuplicateKeys = mode
+	}
+}
+
+// WithDuplicateKeysPolicy sets how the duplicate keys are handled, key by key (see [parser.DuplicateKeysPerKey]).
+func WithDuplicateKeysPolicy(policy parser.DuplicateKeysPolicy) Option {
+	return func(opts *config) {
+		opts.DuplicateKeys = policy
+	}
+}
+
+// WithTagOptions sets the options of the struct tag parser (see [parser.Tag]).
+func WithTagOptions(options ...parser.TagOption) Option {
+	return func(opts *config) {
+		opts.TagOptions = append(opts.TagOptions, options...)
+	}
+}
+
+// WithKeys only creates the entries of the given keys: the other pairs are not in the [Tag], and are not rendered (see [parser.WithKeys]).
+func WithKeys(keys ...string) Option {
+	return func(opts *config) {
+		opts.TagOptions = append(opts.TagOptions, parser.WithKeys(keys...))
+	}
+}
+
+// WithoutKeys doesn't create the entries of the given keys: these pairs are not in the [Tag], and are not rendered (see [parser.WithoutKeys]).
+func WithoutKeys(keys ...string) Option {
+	return func(opts *config) {
+		opts.TagOptions = append(opts.TagOptions, parser.WithoutKeys(keys...))
+	}
+}
+
+// WithValueOptions sets the options of the value parser (see [parser.Value]).
+func WithValueOptions(options ...parser.ValueOption) Option {
+	return func(opts *config) {
+		opts.ValueOptions = append(opts.ValueOptions, options...)
+	}
+}
+
+// WithCST keeps the original text of the struct tag:
+// the whitespace around the entries and the quoted values are rendered as-is by [Tag.Render].
+// The skipped pairs (e.g., duplicate keys with [DuplicateKeysIgnore]) are not kept.
+func WithCST() Option {
+	return func(opts *config) {
+		opts.CST = true
 	}
 }
 
@@ -49,15 +148,20 @@ func WithDuplicateKeysMode(mode DuplicateKeysMode) Option {
 type Tag struct {
 	entries []*Entry
 
-	escapeComma       bool
-	duplicateKeysMode DuplicateKeysMode
+	escapeComma   bool
+	duplicateKeys parser.DuplicateKeysPolicy
+	valueOptions  []parser.ValueOption
+
+	// trailing is the whitespace after the last entry (CST mode).
+	trailing string
 }
 
 // NewTag creates a new [Tag].
-func NewTag(escapeComma bool, duplicateKeysMode DuplicateKeysMode) *Tag {
+func NewTag(escapeComma bool, duplicateKeys parser.DuplicateKeysPolicy, valueOptions ...parser.ValueOption) *Tag {
 	return &Tag{
-		escapeComma:       escapeComma,
-		duplicateKeysMode: duplicateKeysMode,
+		escapeComma:   escapeComma,
+		duplicateKeys: duplicateKeys,
+		valueOptions:  valueOptions,
 	}
 }
 
@@ -72,6 +176,13 @@ func (t *Tag) Get(key string) *Entry {
 	return nil
 }
 
+// index returns the index of the first entry with the given key, or -1.
+func (t *Tag) index(key string) int {
+	return slices.IndexFunc(t.entries, func(entry *Entry) bool {
+		return entry != nil && entry.Key == key
+	})
+}
+
 // GetAll returns all entries with the given key.
 // NOT RECOMMENDED TO USE IT: this does not follow the struct tag conventions.
 func (t *Tag) GetAll(key string) []*Entry {
@@ -92,27 +203,34 @@ func (t *Tag) Add(tag *Entry) error {
 		return nil
 	}
 
-	switch t.duplicateKeysMode {
-	case DuplicateKeysIgnore:
-		if t.Get(tag.Key) != nil {
+	if i := t.index(tag.Key); i >= 0 {
+		switch parser.DuplicateKeyMode(t.duplicateKeys, tag.Key, DuplicateKeysIgnore) {
+		case DuplicateKeysDeny:
+			return parser.NewDuplicateKeyError(tag.Key)
+
+		case DuplicateKeysAllow:
+			// Do nothing.
+
+		case DuplicateKeysLastWins:
+			tag.escapeComma = t.escapeComma
+			tag.valueOptions = t.valueOptions
+
+			t.entries[i] = tag
+
 			return nil
-		}
 
-	case DuplicateKeysDeny:
-		if t.Get(tag.Key) != nil {
-			return fmt.Errorf("duplicate key %q", tag.Key)
-		}
+		case DuplicateKeysMerge:
+			t.entries[i].RawValue = parser.MergeValues(tag.Key, t.entries[i].RawValue, tag.RawValue, t.valueOptions...)
 
-	case DuplicateKeysAllow:
-		// Do nothing.
+			return nil
 
-	default:
-		if t.Get(tag.Key) != nil {
+		default:
 			return nil
 		}
 	}
 
 	tag.escapeComma = t.escapeComma
+	tag.valueOptions = t.valueOptions
 
 	t.entries = append(t.entries, tag)
 
@@ -153,6 +271,24 @@ func (t *Tag) Sort() {
 	})
 }
 
+// Clone returns a deep copy of the [Tag].
+func (t *Tag) Clone() *Tag {
+	if t == nil {
+		return nil
+	}
+
+	c := *t
+	c.entries = make([]*Entry, 0, len(t.entries))
+
+	for _, entry := range t.entries {
+		if entry != nil {
+			c.entries = append(c.entries, entry.Clone())
+		}
+	}
+
+	return &c
+}
+
 // String returns the string representation of the [Tag].
 func (t *Tag) String() string {
 	var b strings.Builder
@@ -174,13 +310,38 @@ type Entry struct {
 	Key      string
 	RawValue string
 
-	escapeComma bool
+	escapeComma  bool
+	valueOptions []parser.ValueOption
+
+	spans parser.Spans
+
+	// node is the original text of the entry (CST mode).
+	node *node
+}
+
+// Spans returns the location of the key and the value inside the parsed struct tag.
+// The spans are zero if the entry doesn't come from a parsed struct tag.
+func (e *Entry) Spans() parser.Spans {
+	return e.spans
 }
 
 // Values returns the values of the entry.
-// When modifying the values, the result must be set [Entry.RawValue].
+// When modifying the values, the result must be set [Entry.RawValue]
+// (with [parser.JoinEscaped] if the values are unescaped, see [WithUnescapeComma]).
 func (e *Entry) Values() (TagValues, error) {
-	return parser.Value(e.RawValue, e.escapeComma)
+	return parser.KeyValue(e.Key, e.RawValue, e.escapeComma, e.valueOptions...)
+}
+
+// Clone returns a copy of the entry.
+func (e *Entry) Clone() *Entry {
+	if e == nil {
+		return nil
+	}
+
+	// The original text (CST mode) is never modified, so it can be shared.
+	c := *e
+
+	return &c
 }
 
 // String returns the string representation of the entry.
@@ -201,6 +362,29 @@ func (t TagValues) IsEmpty() bool {
 	return len(t) == 0 || len(t) == 1 && t[0] == ""
 }
 
+// Options returns the values as options (see [parser.Options]).
+func (t TagValues) Options(sep byte) []parser.OptionPair {
+	return parser.Options(t, sep)
+}
+
+// Option returns the value of the first `name=value` option,
+// or an empty value for a flag (e.g., `omitempty`).
+// It returns false if the option is not found.
+func (t TagValues) Option(name string) (string, bool) {
+	return t.OptionSep(name, '=')
+}
+
+// OptionSep is like [TagValues.Option] with another separator (e.g., `:`).
+func (t TagValues) OptionSep(name string, sep byte) (string, bool) {
+	for _, option := range t.Options(sep) {
+		if option.Name == name {
+			return option.Value, true
+		}
+	}
+
+	return "", false
+}
+
 // String returns the string representation of the values.
 func (t TagValues) String() string {
 	return strings.Join(t, ",")
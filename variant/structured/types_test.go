@@ -4,6 +4,7 @@ import (
 	"slices"
 	"testing"
 
+	"github.com/ldez/structtags/parser"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -49,7 +50,7 @@ func TestTag_Add_duplicate_error(t *testing.T) {
 	b := &Entry{Key: "test", RawValue: "b"}
 
 	err = tag.Add(b)
-	require.Error(t, err)
+	require.ErrorIs(t, err, parser.ErrDuplicateKey)
 
 	assert.Equal(t, []*Entry{a}, tag.entries)
 }
@@ -132,6 +133,24 @@ func TestTag_String(t *testing.T) {
 	}
 }
 
+func TestTag_Clone(t *testing.T) {
+	tag := NewTag(false, DuplicateKeysIgnore)
+
+	err := tag.Add(&Entry{Key: "a", RawValue: "b"})
+	require.NoError(t, err)
+
+	c := tag.Clone()
+	c.Get("a").RawValue = "c"
+
+	err = c.Add(&Entry{Key: "d", RawValue: "e"})
+	require.NoError(t, err)
+
+	assert.Equal(t, `a="b"`, tag.String())
+	assert.Equal(t, `a="c" d="e"`, c.String())
+
+	assert.Nil(t, (*Tag)(nil).Clone())
+}
+
 func TestEntry_Values(t *testing.T) {
 	testCases := []struct {
 		desc     string
@@ -238,6 +257,69 @@ func TestTagValues_Has(t *testing.T) {
 	}
 }
 
+func TestTagValues_Option(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		values   TagValues
+		name     string
+		expected string
+		found    bool
+	}{
+		{
+			desc:     "option",
+			values:   TagValues{"min=1", "max=10"},
+			name:     "max",
+			expected: "10",
+			found:    true,
+		},
+		{
+			desc:   "flag",
+			values: TagValues{"name", "omitempty"},
+			name:   "omitempty",
+			found:  true,
+		},
+		{
+			desc:   "not found",
+			values: TagValues{"min=1", "max=10"},
+			name:   "len",
+		},
+		{
+			desc:     "first option",
+			values:   TagValues{"min=1", "min=2"},
+			name:     "min",
+			expected: "1",
+			found:    true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			value, found := test.values.Option(test.name)
+
+			assert.Equal(t, test.expected, value)
+			assert.Equal(t, test.found, found)
+		})
+	}
+}
+
+func TestTagValues_OptionSep(t *testing.T) {
+	values := TagValues{"column:id", "type:int", "primaryKey"}
+
+	value, found := values.OptionSep("type", ':')
+	assert.True(t, found)
+	assert.Equal(t, "int", value)
+
+	expected := []parser.OptionPair{
+		{Name: "column", Value: "id"},
+		{Name: "type", Value: "int"},
+		{Name: "primaryKey", Flag: true},
+	}
+
+	assert.Equal(t, expected, values.Options(':'))
+}
+
 func TestTagValues_IsEmpty(t *testing.T) {
 	testCases := []struct {
 		desc   string